@@ -1,25 +1,171 @@
 package huggingface
 
 import (
+	"bufio"
+	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // Auth holds the authentication information
 type Auth struct {
-	token string
+	provider TokenProvider
 }
 
-// NewAuthFromEnv creates a new Auth instance by reading the HF_TOKEN environment variable
+// NewAuth creates a new Auth instance asking the provider for the token before every request
+func NewAuth(provider TokenProvider) *Auth {
+	return &Auth{provider: provider}
+}
+
+// NewAuthFromEnv creates a new Auth instance with the token of the environment, looked up like the huggingface CLI does:
+// the HF_TOKEN environment variable, then the file at HF_TOKEN_PATH, then $HF_HOME/token, then ~/.cache/huggingface/token.
 func NewAuthFromEnv() (*Auth, error) {
-	token := os.Getenv("HF_TOKEN")
+	token, err := resolveToken()
+	if err != nil {
+		return nil, err
+	}
 	if token == "" {
-		return nil, errors.New("environment variable HF_TOKEN is not set or empty")
+		return nil, errors.New("no token found: set HF_TOKEN or log in with the huggingface CLI")
+	}
+	return NewAuth(NewStaticTokenProvider(token)), nil
+}
+
+// NewAuthFromProfile creates a new Auth instance with a token saved under a name by the huggingface CLI,
+// in $HF_HOME/stored_tokens.
+func NewAuthFromProfile(profile string) (*Auth, error) {
+	token, err := readStoredToken(profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuth(NewStaticTokenProvider(token)), nil
+}
+
+// NewAnonymousAuth creates an Auth without token, which can only read public repositories
+func NewAnonymousAuth() *Auth {
+	return &Auth{}
+}
+
+// IsAnonymous reports whether requests are sent without token
+func (a *Auth) IsAnonymous() bool {
+	return a == nil || a.provider == nil || a.provider == staticToken("")
+}
+
+// Header returns the authorization header, or an empty string when there is no token.
+// ctx is the context of the request, passed to the TokenProvider.
+func (a *Auth) Header(ctx context.Context) (string, error) {
+	if a.IsAnonymous() {
+		return "", nil
+	}
+	token, err := a.provider.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get token: %w", err)
+	}
+	if token == "" {
+		return "", nil
+	}
+	return "Bearer " + token, nil
+}
+
+// requireAuth returns ErrAuthRequired if the client has no token, before an operation that writes to the Hub
+func (c *HubClient) requireAuth(operation string) error {
+	if c.Auth.IsAnonymous() {
+		return fmt.Errorf("%s: %w", operation, ErrAuthRequired)
+	}
+	return nil
+}
+
+// hfHome returns the folder of the huggingface cache and credentials: HF_HOME, or ~/.cache/huggingface
+func hfHome() string {
+	if home := os.Getenv("HF_HOME"); home != "" {
+		return home
+	}
+	return defaultHFHome()
+}
+
+// defaultHFHome returns the folder used when HF_HOME is not set, ~/.cache/huggingface
+func defaultHFHome() string {
+	cacheHome := os.Getenv("XDG_CACHE_HOME")
+	if cacheHome == "" {
+		userHome, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		cacheHome = filepath.Join(userHome, ".cache")
 	}
-	return &Auth{token: token}, nil
+	return filepath.Join(cacheHome, "huggingface")
 }
 
-// Header returns the authorization header
-func (a *Auth) Header() string {
-	return "Bearer " + a.token
-}
\ No newline at end of file
+// resolveToken returns the first token found in the environment or the token files, or an empty string if there is none
+func resolveToken() (string, error) {
+	if token := strings.TrimSpace(os.Getenv("HF_TOKEN")); token != "" {
+		return token, nil
+	}
+
+	var paths []string
+	if path := os.Getenv("HF_TOKEN_PATH"); path != "" {
+		paths = append(paths, path)
+	}
+	if home := os.Getenv("HF_HOME"); home != "" {
+		paths = append(paths, filepath.Join(home, "token"))
+	}
+	if home := defaultHFHome(); home != "" {
+		paths = append(paths, filepath.Join(home, "token"))
+	}
+	for _, path := range paths {
+		token, err := readTokenFile(path)
+		if err != nil || token != "" {
+			return token, err
+		}
+	}
+	return "", nil
+}
+
+// readTokenFile returns the token stored in a file, or an empty string if the file does not exist
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readStoredToken returns the token of a profile in the stored_tokens file, an INI file with one section per profile:
+//
+//	[profile]
+//	hf_token = hf_...
+func readStoredToken(profile string) (string, error) {
+	path := filepath.Join(hfHome(), "stored_tokens")
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading stored tokens: %w", err)
+	}
+	defer file.Close()
+
+	section := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && section == profile && strings.TrimSpace(key) == "hf_token" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading stored tokens: %w", err)
+	}
+	return "", fmt.Errorf("no token stored for profile %q in %s", profile, path)
+}
@@ -2,14 +2,15 @@ package huggingface
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
-	"time"
 )
 
 const defaultBaseURL = "https://huggingface.co"
@@ -19,25 +20,63 @@ type HubClient struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	Auth       *Auth
+
+	// MultipartConcurrency is the number of parts of a multipart LFS upload sent in parallel.
+	// Parts are uploaded one at a time when it is 0 or 1.
+	MultipartConcurrency int
+
+	// UploadConcurrency is the number of LFS files uploaded in parallel, defaults to 4 when 0.
+	UploadConcurrency int
+
+	// RetryPolicy configures how requests failing with a transient error are retried, the zero value uses the defaults.
+	RetryPolicy RetryPolicy
+
+	// UserAgent is sent with every request to the Hub, defaults to "huggingface-go" when empty.
+	UserAgent string
+}
+
+const defaultUploadConcurrency = 4
+
+func (c *HubClient) uploadConcurrency() int {
+	if c.UploadConcurrency <= 0 {
+		return defaultUploadConcurrency
+	}
+	return c.UploadConcurrency
 }
 
 // NewHubClient creates a new Hugging Face client.
-func NewHubClient() (*HubClient, error) {
-	auth, err := NewAuthFromEnv()
+// Without options, it talks to HF_ENDPOINT (or https://huggingface.co) with the token of
+// the environment or of the huggingface CLI, if any (see NewAuthFromEnv).
+func NewHubClient(opts ...ClientOption) (*HubClient, error) {
+	cfg := &clientConfig{
+		endpoint: os.Getenv("HF_ENDPOINT"),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// Without token, the client is anonymous and can only read public repositories
+	auth, err := cfg.resolveAuth()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth: %w", err)
 	}
 
+	endpoint := strings.TrimRight(cfg.endpoint, "/")
+	if endpoint == "" {
+		endpoint = defaultBaseURL
+	}
+
 	return &HubClient{
-		BaseURL: defaultBaseURL,
-		HTTPClient: &http.Client{Timeout: 200 * time.Second},
-		Auth: auth,
+		BaseURL:     endpoint,
+		HTTPClient:  cfg.buildHTTPClient(),
+		Auth:        auth,
+		UserAgent:   cfg.userAgent,
+		RetryPolicy: cfg.retryPolicy,
 	}, nil
 }
 
-
-// doRequest handles HTTP requests
-func (c *HubClient) doRequest(method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
+// doRequest handles HTTP requests, which are cancelled when the context is done
+func (c *HubClient) doRequest(ctx context.Context, method, endpoint string, body interface{}, headers map[string]string) (*http.Response, error) {
 	reqBody, err := c.prepareRequestBody(body)
 	if err != nil {
 		return nil, err
@@ -45,20 +84,25 @@ func (c *HubClient) doRequest(method, endpoint string, body interface{}, headers
 
 	fullURL := c.prepareFullURL(endpoint)
 
-	req, err := http.NewRequest(method, fullURL, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for %s %s: %w", method, fullURL, err)
 	}
 
-	c.setHeaders(req, headers)
+	if err := c.setHeaders(req, headers); err != nil {
+		return nil, err
+	}
 
-	return c.HTTPClient.Do(req)
+	return c.doWithRetry(req)
 }
 
 func (c *HubClient) prepareRequestBody(body interface{}) (io.Reader, error) {
 	if body == nil {
 		return nil, nil
 	}
+	if reader, ok := body.(io.Reader); ok {
+		return reader, nil
+	}
 	jsonData, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request body: %w", err)
@@ -67,23 +111,44 @@ func (c *HubClient) prepareRequestBody(body interface{}) (io.Reader, error) {
 }
 
 func (c *HubClient) prepareFullURL(endpoint string) string {
-	if strings.HasPrefix(endpoint, c.BaseURL) {
+	if strings.HasPrefix(endpoint, c.BaseURL) || strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
 		return endpoint
 	}
 	return c.BaseURL + endpoint
 }
 
-func (c *HubClient) setHeaders(req *http.Request, headers map[string]string) {
-	req.Header.Set("Authorization", c.Auth.Header())
+// setHeaders sets the headers of a request, asking the token provider for the current token.
+// The token is only sent to the Hub, never to the other hosts it links to (LFS storage, pagination, etc.)
+func (c *HubClient) setHeaders(req *http.Request, headers map[string]string) error {
+	if c.isHubURL(req.URL) {
+		authHeader, err := c.Auth.Header(req.Context())
+		if err != nil {
+			return err
+		}
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent())
 
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
+	return nil
+}
+
+// isHubURL reports whether the URL points to the host of BaseURL
+func (c *HubClient) isHubURL(requestURL *url.URL) bool {
+	baseURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(requestURL.Host, baseURL.Host)
 }
 
-// parseResponse parses the HTTP response
-func parseResponse(resp *http.Response, v interface{}) error {
+// parseResponse parses the HTTP response, or returns the error of a failed response
+func parseResponse(resp *http.Response, repoId string, v interface{}) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
@@ -92,19 +157,7 @@ func parseResponse(resp *http.Response, v interface{}) error {
 		}
 		return json.NewDecoder(resp.Body).Decode(v)
 	}
-
-	// Handle errors
-	var errResp map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
-		return NewAPIError(resp.StatusCode, resp.Status)
-	}
-
-	message, ok := errResp["message"].(string)
-	if !ok {
-		message = fmt.Sprintf("status: %s", resp.Status)
-	}
-
-	return NewAPIError(resp.StatusCode, message)
+	return newAPIError(resp, repoId)
 }
 
 // ComputeSHA256 computes the SHA256 hash of a file.
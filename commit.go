@@ -0,0 +1,393 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+)
+
+const defaultCommitMessage = "upload files"
+
+// CommitOperation is a single change applied to a repository as part of a commit.
+// It is implemented by *CommitOperationAdd, *CommitOperationDelete and *CommitOperationCopy.
+type CommitOperation interface {
+	pathInRepo() string
+}
+
+// CommitOperationAdd uploads a local file, or in-memory content, to PathInRepo.
+// When Content is set it is uploaded instead of LocalPath; it must allow concurrent ReadAt calls.
+type CommitOperationAdd struct {
+	PathInRepo string
+	LocalPath  string
+	Content    io.ReaderAt
+	Size       int64 // size of Content
+
+	// Filled in while the commit is being prepared
+	uploadInfo   *uploadInfo
+	uploadMode   string
+	shouldIgnore bool
+}
+
+// CommitOperationDelete removes a file from the repository, or a whole folder when IsFolder is set.
+type CommitOperationDelete struct {
+	PathInRepo string
+	IsFolder   bool
+}
+
+// CommitOperationCopy copies a file that already exists on the Hub to PathInRepo.
+// LFS files are copied server-side without being re-uploaded.
+type CommitOperationCopy struct {
+	SrcPathInRepo string
+	PathInRepo    string
+	SrcRevision   string // defaults to the revision of the commit
+}
+
+func (op *CommitOperationAdd) pathInRepo() string    { return op.PathInRepo }
+func (op *CommitOperationDelete) pathInRepo() string { return op.PathInRepo }
+func (op *CommitOperationCopy) pathInRepo() string   { return op.PathInRepo }
+
+// CommitOptions holds options for creating a commit
+type CommitOptions struct {
+	CommitMessage     string
+	CommitDescription string
+	Revision          string // branch to commit to, defaults to "main"
+	CreatePR          bool   // open a pull request against Revision instead of committing to it directly
+	ParentCommit      string // if set, the commit fails with ErrCommitConflict unless it is the head of Revision
+	Progress          ProgressReporter
+}
+
+func (opts *CommitOptions) revision() string {
+	return revisionOrDefault(opts.Revision)
+}
+
+// CommitInfo holds information about a commit created on the Hub.
+type CommitInfo struct {
+	CommitOid         string   `json:"commitOid"`
+	CommitURL         string   `json:"commitUrl"`
+	PullRequestURL    string   `json:"pullRequestUrl,omitempty"`
+	PullRequestNumber int      `json:"-"`
+	PullRequestRef    string   `json:"-"` // revision of the pull request, e.g. "refs/pr/3"
+	Files             []string `json:"-"` // paths added, copied or deleted by the commit
+}
+
+// uploadInfo holds the size, hash and sample of a file to upload
+type uploadInfo struct {
+	Size   int64
+	Sha256 string
+	Sample []byte
+}
+
+// CreateCommit applies all operations to the repository in a single commit.
+// Files are pre-uploaded in one batch, LFS files are uploaded, and the commit is only created once every upload succeeded.
+// The returned CommitInfo is nil if there was nothing to commit (e.g. all files were ignored by the Hub).
+// The operations are not modified, so they can be committed again once their files changed.
+func (c *HubClient) CreateCommit(ctx context.Context, repoId, repoType string, ops []CommitOperation, opts *CommitOptions) (*CommitInfo, error) {
+	return c.createCommit(ctx, repoId, repoType, ops, opts, nil)
+}
+
+// createCommit implements CreateCommit. hashes holds the upload info already computed for some of the additions,
+// e.g. by SyncFolder while comparing files to the repository.
+func (c *HubClient) createCommit(ctx context.Context, repoId, repoType string, ops []CommitOperation, opts *CommitOptions, hashes map[*CommitOperationAdd]*uploadInfo) (*CommitInfo, error) {
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("no operations to commit")
+	}
+	if err := c.requireAuth("create commit"); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &CommitOptions{}
+	}
+
+	// The additions are copied, so that what is computed for this commit never leaks into another one
+	ops = slices.Clone(ops)
+	var additions []*CommitOperationAdd
+	for i, op := range ops {
+		if add, ok := op.(*CommitOperationAdd); ok {
+			addCopy := &CommitOperationAdd{
+				PathInRepo: add.PathInRepo,
+				LocalPath:  add.LocalPath,
+				Content:    add.Content,
+				Size:       add.Size,
+				uploadInfo: hashes[add],
+			}
+			ops[i] = addCopy
+			additions = append(additions, addCopy)
+		}
+	}
+
+	// 1. Hash and sample the files to add, unless it was already done
+	for _, add := range additions {
+		if add.uploadInfo != nil {
+			continue
+		}
+		info, err := newUploadInfo(ctx, add, opts.Progress)
+		if err != nil {
+			return nil, err
+		}
+		add.uploadInfo = info
+	}
+
+	// 2. Pre-upload (determine regular or LFS for every file)
+	parentCommit := opts.ParentCommit
+	if len(additions) > 0 {
+		preuploadData, err := c.preUpload(ctx, repoId, repoType, opts.revision(), opts.CreatePR, additions, opts.Progress)
+		if err != nil {
+			return nil, err
+		}
+		if parentCommit == "" {
+			parentCommit = preuploadData.CommitOid
+		}
+	}
+
+	// 3. Upload LFS files, files ignored by the Hub are skipped
+	if err := c.uploadLFSFiles(ctx, repoId, repoType, opts.revision(), additions, opts.Progress); err != nil {
+		return nil, err
+	}
+
+	// 4. Fetch the source of copied files
+	copies, err := c.fetchCopySources(ctx, repoId, repoType, opts.revision(), ops)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Commit everything at once
+	return c.commit(ctx, repoId, repoType, parentCommit, opts, ops, copies)
+}
+
+// openContent opens the content to upload, the returned closer must be closed once done
+func (op *CommitOperationAdd) openContent() (io.ReaderAt, int64, io.Closer, error) {
+	if op.Content != nil {
+		if op.Size < 0 {
+			return nil, 0, nil, fmt.Errorf("invalid size %d for %s", op.Size, op.PathInRepo)
+		}
+		return op.Content, op.Size, io.NopCloser(nil), nil
+	}
+
+	file, err := os.Open(op.LocalPath)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("error opening file: %w", err)
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, nil, fmt.Errorf("error getting file info: %w", err)
+	}
+
+	return file, fileInfo.Size(), file, nil
+}
+
+// newUploadInfo streams the content once to compute its size, SHA256 hash and sample, until ctx is done
+func newUploadInfo(ctx context.Context, op *CommitOperationAdd, reporter ProgressReporter) (*uploadInfo, error) {
+	content, size, closer, err := op.openContent()
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	progress := newFileProgress(reporter, op.PathInRepo, PhaseHashing, size)
+	progress.start()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, contextReader(ctx, progress.reader(io.NewSectionReader(content, 0, size)))); err != nil {
+		return nil, fmt.Errorf("error calculating SHA256 hash: %w", err)
+	}
+
+	sample := make([]byte, min(size, 512))
+	n, err := content.ReadAt(sample, 0)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error reading file sample: %w", err)
+	}
+
+	return &uploadInfo{
+		Size:   size,
+		Sha256: fmt.Sprintf("%x", hasher.Sum(nil)),
+		Sample: sample[:n],
+	}, nil
+}
+
+// readContent reads the content that was hashed, only used for regular (small) files.
+// Bytes appended since the file was hashed are not read.
+func (op *CommitOperationAdd) readContent() ([]byte, error) {
+	content, _, closer, err := op.openContent()
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	data, err := io.ReadAll(io.NewSectionReader(content, 0, op.uploadInfo.Size))
+	if err != nil {
+		return nil, fmt.Errorf("error reading file data: %w", err)
+	}
+	if int64(len(data)) != op.uploadInfo.Size {
+		return nil, fmt.Errorf("file %s was truncated since it was hashed", op.PathInRepo)
+	}
+	return data, nil
+}
+
+// commitOperationLine is a single line of the NDJSON commit payload
+type commitOperationLine struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// commit sends the NDJSON commit request with all operations
+func (c *HubClient) commit(ctx context.Context, repoId, repoType, parentCommit string, opts *CommitOptions, ops []CommitOperation, copies map[copySourceKey]*copySource) (*CommitInfo, error) {
+	message := opts.CommitMessage
+	if message == "" {
+		message = defaultCommitMessage
+	}
+
+	header := map[string]interface{}{
+		"summary":     message,
+		"description": opts.CommitDescription,
+	}
+	if parentCommit != "" {
+		header["parentCommit"] = parentCommit
+	}
+
+	lines := []commitOperationLine{{Key: "header", Value: header}}
+	var files []string
+	var progresses []*fileProgress
+	for _, op := range ops {
+		line, err := operationLine(op, opts.revision(), copies)
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			continue
+		}
+		lines = append(lines, *line)
+		files = append(files, op.pathInRepo())
+		if add, ok := op.(*CommitOperationAdd); ok {
+			progresses = append(progresses, newFileProgress(opts.Progress, add.PathInRepo, PhaseCommitting, add.uploadInfo.Size))
+		}
+	}
+
+	// Nothing left to commit, e.g. every file was ignored by the Hub
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	var requestBody bytes.Buffer
+	encoder := json.NewEncoder(&requestBody)
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("error encoding commit payload: %w", err)
+		}
+	}
+
+	commitURL := fmt.Sprintf("/api/%ss/%s/commit/%s", repoType, repoId, escapeRevision(opts.Revision))
+	if opts.CreatePR {
+		commitURL += "?create_pr=1"
+	}
+	headers := map[string]string{"Content-Type": "application/x-ndjson"}
+
+	for _, progress := range progresses {
+		progress.start()
+	}
+	commitResp, err := c.doRequest(ctx, "POST", commitURL, &requestBody, headers)
+	if err != nil {
+		return nil, fmt.Errorf("commit request failed: %w", err)
+	}
+	defer commitResp.Body.Close()
+
+	if commitResp.StatusCode != http.StatusOK {
+		apiErr := newAPIError(commitResp, repoId)
+		if commitResp.StatusCode == http.StatusPreconditionFailed {
+			// The parent commit is no longer the head of the revision
+			apiErr.Err = ErrCommitConflict
+		}
+		return nil, apiErr
+	}
+
+	var commitInfo CommitInfo
+	if err := json.NewDecoder(commitResp.Body).Decode(&commitInfo); err != nil {
+		return nil, fmt.Errorf("error decoding commit response: %w", err)
+	}
+	commitInfo.Files = files
+	for _, progress := range progresses {
+		progress.done()
+	}
+	commitInfo.PullRequestNumber = pullRequestNumber(commitInfo.PullRequestURL)
+	if commitInfo.PullRequestNumber != 0 {
+		commitInfo.PullRequestRef = fmt.Sprintf("refs/pr/%d", commitInfo.PullRequestNumber)
+	}
+
+	return &commitInfo, nil
+}
+
+// pullRequestNumber extracts the number from a pull request URL (".../discussions/3"), or returns 0
+func pullRequestNumber(pullRequestURL string) int {
+	if pullRequestURL == "" {
+		return 0
+	}
+	number, err := strconv.Atoi(path.Base(pullRequestURL))
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// operationLine builds the NDJSON line for an operation, or nil if the operation should be skipped
+func operationLine(op CommitOperation, revision string, copies map[copySourceKey]*copySource) (*commitOperationLine, error) {
+	switch op := op.(type) {
+	case *CommitOperationAdd:
+		if op.shouldIgnore {
+			return nil, nil
+		}
+		if op.uploadMode == "lfs" {
+			return &commitOperationLine{Key: "lfsFile", Value: map[string]interface{}{
+				"path": op.PathInRepo,
+				"algo": "sha256",
+				"oid":  op.uploadInfo.Sha256,
+				"size": op.uploadInfo.Size,
+			}}, nil
+		}
+		content, err := op.readContent()
+		if err != nil {
+			return nil, err
+		}
+		return &commitOperationLine{Key: "file", Value: map[string]interface{}{
+			"path":     op.PathInRepo,
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString(content),
+		}}, nil
+	case *CommitOperationDelete:
+		key := "deletedFile"
+		if op.IsFolder {
+			key = "deletedFolder"
+		}
+		return &commitOperationLine{Key: key, Value: map[string]interface{}{
+			"path": op.PathInRepo,
+		}}, nil
+	case *CommitOperationCopy:
+		src := copies[copySourceKey{path: op.SrcPathInRepo, revision: op.srcRevision(revision)}]
+		if src == nil {
+			return nil, fmt.Errorf("copy source not found: %s", op.SrcPathInRepo)
+		}
+		if src.Lfs != nil {
+			return &commitOperationLine{Key: "lfsFile", Value: map[string]interface{}{
+				"path": op.PathInRepo,
+				"algo": "sha256",
+				"oid":  src.Lfs.Oid,
+				"size": src.Lfs.Size,
+			}}, nil
+		}
+		return &commitOperationLine{Key: "file", Value: map[string]interface{}{
+			"path":     op.PathInRepo,
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString(src.content),
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown commit operation: %T", op)
+	}
+}
@@ -0,0 +1,96 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CopyFile copies a file of the repository to another path, at the revision of the commit.
+// LFS files are copied server-side, reusing their oid without re-uploading the content.
+func (c *HubClient) CopyFile(ctx context.Context, repoId, repoType, srcPathInRepo, pathInRepo string, opts *CommitOptions) (*CommitInfo, error) {
+	op := &CommitOperationCopy{
+		SrcPathInRepo: strings.Trim(srcPathInRepo, "/"),
+		PathInRepo:    strings.Trim(pathInRepo, "/"),
+	}
+	message := fmt.Sprintf("copy %s to %s", op.SrcPathInRepo, op.PathInRepo)
+	return c.CreateCommit(ctx, repoId, repoType, []CommitOperation{op}, withDefaultMessage(opts, message))
+}
+
+// copySourceKey identifies the source of a copy operation
+type copySourceKey struct {
+	path     string
+	revision string
+}
+
+// copySource holds what is needed to commit a copied file
+type copySource struct {
+	PathInfo
+	content []byte // only set for regular (non-LFS) files
+}
+
+func (op *CommitOperationCopy) srcRevision(commitRevision string) string {
+	if op.SrcRevision == "" {
+		return commitRevision
+	}
+	return op.SrcRevision
+}
+
+// fetchCopySources resolves the source files of all copy operations.
+// LFS files only need their oid, regular files are downloaded so they can be re-committed.
+func (c *HubClient) fetchCopySources(ctx context.Context, repoId, repoType, commitRevision string, ops []CommitOperation) (map[copySourceKey]*copySource, error) {
+	pathsByRevision := map[string][]string{}
+	for _, op := range ops {
+		if cp, ok := op.(*CommitOperationCopy); ok {
+			revision := cp.srcRevision(commitRevision)
+			pathsByRevision[revision] = append(pathsByRevision[revision], cp.SrcPathInRepo)
+		}
+	}
+
+	copies := map[copySourceKey]*copySource{}
+	for revision, paths := range pathsByRevision {
+		infos, err := c.pathsInfo(ctx, repoId, repoType, revision, paths)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.Type != "file" {
+				continue
+			}
+			src := &copySource{PathInfo: info}
+			if info.Lfs == nil {
+				content, err := c.downloadContent(ctx, repoId, repoType, revision, info.Path)
+				if err != nil {
+					return nil, err
+				}
+				src.content = content
+			}
+			copies[copySourceKey{path: info.Path, revision: revision}] = src
+		}
+	}
+
+	return copies, nil
+}
+
+// downloadContent downloads a file from the repository into memory
+func (c *HubClient) downloadContent(ctx context.Context, repoId, repoType, revision, filePath string) ([]byte, error) {
+	downloadURL := fmt.Sprintf("%s/%s%s/resolve/%s/%s", c.BaseURL, repoTypeURLPrefix(repoType), repoId, escapeRevision(revision), filePath)
+
+	resp, err := c.doRequest(ctx, "GET", downloadURL, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("download request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, repoId)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file content: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,66 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DeleteFile deletes a single file from the repository.
+func (c *HubClient) DeleteFile(ctx context.Context, repoId, repoType, pathInRepo string, opts *CommitOptions) (*CommitInfo, error) {
+	pathInRepo = strings.Trim(pathInRepo, "/")
+	if pathInRepo == "" {
+		return nil, fmt.Errorf("path in repo must not be empty")
+	}
+	op := &CommitOperationDelete{PathInRepo: pathInRepo}
+	return c.CreateCommit(ctx, repoId, repoType, []CommitOperation{op}, withDefaultMessage(opts, fmt.Sprintf("delete %s", pathInRepo)))
+}
+
+// DeleteFolder deletes a folder and everything inside it from the repository.
+func (c *HubClient) DeleteFolder(ctx context.Context, repoId, repoType, pathInRepo string, opts *CommitOptions) (*CommitInfo, error) {
+	pathInRepo = strings.Trim(pathInRepo, "/")
+	if pathInRepo == "" {
+		return nil, fmt.Errorf("path in repo must not be empty")
+	}
+	op := &CommitOperationDelete{PathInRepo: pathInRepo, IsFolder: true}
+	return c.CreateCommit(ctx, repoId, repoType, []CommitOperation{op}, withDefaultMessage(opts, fmt.Sprintf("delete folder %s", pathInRepo)))
+}
+
+// DeleteFiles deletes every file of the repository matching at least one glob pattern, in a single commit.
+// Patterns follow the same rules as UploadFolderOptions. The returned CommitInfo is nil if no file matched.
+func (c *HubClient) DeleteFiles(ctx context.Context, repoId, repoType string, patterns []string, opts *CommitOptions) (*CommitInfo, error) {
+	if opts == nil {
+		opts = &CommitOptions{}
+	}
+
+	remoteFiles, err := c.ListRepoFiles(ctx, repoId, repoType, opts.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteGlobs := compileGlobs(patterns)
+	var ops []CommitOperation
+	for _, remoteFile := range remoteFiles {
+		if deleteGlobs.matchAny(remoteFile) {
+			ops = append(ops, &CommitOperationDelete{PathInRepo: remoteFile})
+		}
+	}
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	return c.CreateCommit(ctx, repoId, repoType, ops, withDefaultMessage(opts, fmt.Sprintf("delete %d files", len(ops))))
+}
+
+// withDefaultMessage returns a copy of the options with the commit message set, if it was empty
+func withDefaultMessage(opts *CommitOptions, message string) *CommitOptions {
+	withMessage := CommitOptions{}
+	if opts != nil {
+		withMessage = *opts
+	}
+	if withMessage.CommitMessage == "" {
+		withMessage.CommitMessage = message
+	}
+	return &withMessage
+}
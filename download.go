@@ -1,6 +1,7 @@
 package huggingface
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -8,9 +9,18 @@ import (
 	"path/filepath"
 )
 
+// DownloadFileOptions holds options for downloading a file
+type DownloadFileOptions struct {
+	Revision string // branch, tag or commit hash to download from, defaults to "main"
+}
+
 // DownloadFile downloads a file from the specified repository and path, and saves it to the given local path.
 // Returns nil if successful, or an error if the download or file writing fails.
-func (c *HubClient) DownloadFile(repoId, repoType, filePath, localFilePath string) error {
+func (c *HubClient) DownloadFile(ctx context.Context, repoId, repoType, filePath, localFilePath string, opts *DownloadFileOptions) error {
+	if opts == nil {
+		opts = &DownloadFileOptions{}
+	}
+
 	// Determine the repository type path
 	repoTypePath := ""
 	if repoType != "model" {
@@ -18,25 +28,18 @@ func (c *HubClient) DownloadFile(repoId, repoType, filePath, localFilePath strin
 	}
 
 	// Construct the download URL
-	downloadURL := fmt.Sprintf("%s/%s%s/resolve/main/%s", c.BaseURL, repoTypePath, repoId, filePath)
+	downloadURL := fmt.Sprintf("%s/%s%s/resolve/%s/%s", c.BaseURL, repoTypePath, repoId, escapeRevision(opts.Revision), filePath)
 
 	// Make the request
-	resp, err := c.doRequest("GET", downloadURL, nil, nil)
+	resp, err := c.doRequest(ctx, "GET", downloadURL, nil, nil)
 	if err != nil {
 		return fmt.Errorf("download request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Handle 404 (File not found) specifically
-	if resp.StatusCode == http.StatusNotFound {
-		if resp.Header.Get("X-Error-Code") == "EntryNotFound" {
-			return fmt.Errorf("file not found in repository: %s", filePath)
-		}
-	}
-
-	// Handle any non-200 responses as errors
+	// Handle any non-200 responses as errors, a missing file is reported as ErrEntryNotFound
 	if resp.StatusCode != http.StatusOK {
-		return CreateApiError(resp)
+		return newAPIError(resp, repoId)
 	}
 
 	// Ensure the directory exists before saving the file
@@ -1,19 +1,70 @@
 package huggingface
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
+// Errors returned by the client, use errors.Is to check for them.
+// Errors returned by the Hub are *APIError, which wrap the sentinel matching the failure, if any.
+var (
+	// ErrRepositoryNotFound is returned when the repository does not exist, or is private and the token cannot access it.
+	ErrRepositoryNotFound = errors.New("repository not found")
+	// ErrRevisionNotFound is returned when the branch, tag or commit does not exist in the repository.
+	ErrRevisionNotFound = errors.New("revision not found")
+	// ErrEntryNotFound is returned when the file does not exist at the revision.
+	ErrEntryNotFound = errors.New("entry not found")
+	// ErrGatedRepo is returned when the repository is gated and the user has not been granted access.
+	ErrGatedRepo = errors.New("gated repository: access must be requested on the Hub")
+	// ErrDisabledRepo is returned when the repository has been disabled by its author or the Hub.
+	ErrDisabledRepo = errors.New("repository disabled")
+	// ErrBadRequest is returned when the Hub rejects the parameters of the request.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized is returned when the token is invalid, or not allowed to perform the request.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrCommitConflict is returned when a commit is rejected because its parent commit is no longer the head of the revision.
+	// Refresh the parent commit and retry.
+	ErrCommitConflict = errors.New("commit conflict: the revision has moved past the parent commit")
+
+	// ErrAuthRequired is returned, without calling the Hub, when an operation writing to the Hub is called on a client without token.
+	ErrAuthRequired = errors.New("authentication required: set HF_TOKEN or create the client with WithToken")
+)
+
+// maxErrorBodySize bounds how much of an error response is read for the message
+const maxErrorBodySize = 64 * 1024
+
 // APIError represents an error returned by the Hugging Face API
 type APIError struct {
 	StatusCode int
 	Message    string
+	ErrorCode  string // value of the X-Error-Code header, e.g. "RepoNotFound"
+	RequestID  string // value of the X-Request-Id header, to give when reporting an issue
+	URL        string // URL of the request, without query string
+	Repo       string // repository the request was about, if any
+
+	// Err is the sentinel error matching the failure, e.g. ErrRepositoryNotFound, or nil
+	Err error
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("Hugging Face API Error %d: %s", e.StatusCode, e.Message)
+	msg := fmt.Sprintf("Hugging Face API Error %d: %s", e.StatusCode, e.Message)
+	if e.Repo != "" {
+		msg += fmt.Sprintf(" (repo: %s)", e.Repo)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request ID: %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error matching the failure, so that errors.Is works.
+func (e *APIError) Unwrap() error {
+	return e.Err
 }
 
 // NewAPIError creates a new APIError
@@ -21,11 +72,84 @@ func NewAPIError(statusCode int, message string) *APIError {
 	return &APIError{
 		StatusCode: statusCode,
 		Message:    message,
+		Err:        classifyError(statusCode, "", ""),
 	}
 }
 
-// createApiError creates a descriptive error message from the API response.
+// CreateApiError creates a descriptive error from the API response.
+//
+// Deprecated: the client already returns *APIError for failed requests.
 func CreateApiError(resp *http.Response) error {
-	responseBody, _ := io.ReadAll(resp.Body)
-	return fmt.Errorf("API request failed: status code %d, body: %s", resp.StatusCode, string(responseBody))
-}
\ No newline at end of file
+	return newAPIError(resp, "")
+}
+
+// newAPIError builds the error of a failed response, reading its body. repoId is the repository of the request, if any.
+func newAPIError(resp *http.Response, repoId string) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		ErrorCode:  resp.Header.Get("X-Error-Code"),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Repo:       repoId,
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		// The query string of presigned URLs holds credentials
+		requestURL := *resp.Request.URL
+		requestURL.RawQuery = ""
+		apiErr.URL = requestURL.String()
+	}
+
+	headerMessage := resp.Header.Get("X-Error-Message")
+	apiErr.Message = headerMessage
+	if apiErr.Message == "" {
+		apiErr.Message = responseMessage(resp)
+	}
+	apiErr.Err = classifyError(resp.StatusCode, apiErr.ErrorCode, headerMessage)
+	return apiErr
+}
+
+// classifyError returns the sentinel error matching a failed response, or nil
+func classifyError(statusCode int, errorCode, errorMessage string) error {
+	switch errorCode {
+	case "RepoNotFound":
+		return ErrRepositoryNotFound
+	case "RevisionNotFound":
+		return ErrRevisionNotFound
+	case "EntryNotFound":
+		return ErrEntryNotFound
+	case "GatedRepo":
+		return ErrGatedRepo
+	}
+	if errorMessage == "Access to this resource is disabled." {
+		return ErrDisabledRepo
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// responseMessage returns the message of an error response: the "error" or "message" field of a JSON body, or the raw body
+func responseMessage(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	var errResp struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &errResp) == nil {
+		if errResp.Error != "" {
+			return errResp.Error
+		}
+		if errResp.Message != "" {
+			return errResp.Message
+		}
+	}
+	if message := strings.TrimSpace(string(body)); message != "" {
+		return message
+	}
+	return resp.Status
+}
@@ -0,0 +1,224 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultIgnorePatterns are never uploaded from a local folder
+var defaultIgnorePatterns = []string{
+	".git", ".git/*", "*/.git", "*/.git/*",
+	".cache/huggingface", ".cache/huggingface/*", "*/.cache/huggingface", "*/.cache/huggingface/*",
+}
+
+// defaultIgnoreGlobs are the compiled defaultIgnorePatterns, folders matching them are not walked at all
+var defaultIgnoreGlobs = compileGlobs(defaultIgnorePatterns)
+
+// UploadFolderOptions holds options for uploading a folder
+type UploadFolderOptions struct {
+	PathInRepo        string   // folder in the repository to upload to, defaults to the root
+	AllowPatterns     []string // if set, only files matching at least one pattern are uploaded
+	IgnorePatterns    []string // files matching any pattern are not uploaded
+	DeletePatterns    []string // remote files matching any pattern are deleted, unless they are re-uploaded
+	CommitMessage     string
+	CommitDescription string
+	Revision          string // branch to commit to, defaults to "main"
+	CreatePR          bool   // open a pull request against Revision instead of committing to it directly
+	ParentCommit      string // if set, the commit fails with ErrCommitConflict unless it is the head of Revision
+	Progress          ProgressReporter
+}
+
+// UploadFolder uploads the content of a local folder to the repository in a single commit.
+// Glob patterns follow fnmatch rules ("*" also matches "/") and are matched against paths relative to the folder.
+// The returned CommitInfo is nil if there was nothing to upload.
+func (c *HubClient) UploadFolder(ctx context.Context, repoId, repoType, localDir string, opts *UploadFolderOptions) (*CommitInfo, error) {
+	if opts == nil {
+		opts = &UploadFolderOptions{}
+	}
+	prefix := strings.Trim(opts.PathInRepo, "/")
+
+	additions, err := folderAdditions(ctx, localDir, prefix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]CommitOperation, 0, len(additions))
+	for _, add := range additions {
+		ops = append(ops, add)
+	}
+
+	if len(opts.DeletePatterns) > 0 {
+		remoteFiles, err := c.ListRepoFiles(ctx, repoId, repoType, opts.Revision)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(folderDeletions(remoteFiles, prefix, opts.DeletePatterns, additions), ops...)
+	}
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	return c.CreateCommit(ctx, repoId, repoType, ops, opts.commitOptions())
+}
+
+// commitOptions returns the options of the commit created by a folder upload
+func (opts *UploadFolderOptions) commitOptions() *CommitOptions {
+	message := opts.CommitMessage
+	if message == "" {
+		message = "upload folder"
+	}
+	return &CommitOptions{
+		CommitMessage:     message,
+		CommitDescription: opts.CommitDescription,
+		Revision:          opts.Revision,
+		CreatePR:          opts.CreatePR,
+		ParentCommit:      opts.ParentCommit,
+		Progress:          opts.Progress,
+	}
+}
+
+// folderAdditions walks the local folder and returns an add operation for every file to upload
+func folderAdditions(ctx context.Context, localDir, prefix string, opts *UploadFolderOptions) ([]*CommitOperationAdd, error) {
+	// Patterns are compiled once for the whole walk
+	allowGlobs := compileGlobs(opts.AllowPatterns)
+	ignoreGlobs := append(compileGlobs(opts.IgnorePatterns), defaultIgnoreGlobs...)
+
+	var additions []*CommitOperationAdd
+	err := filepath.WalkDir(localDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(localDir, filePath)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			// Skip .git and the cache folders without listing their content
+			if relPath != "." && defaultIgnoreGlobs.matchAny(relPath) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if !filterPath(relPath, allowGlobs, ignoreGlobs) {
+			return nil
+		}
+
+		additions = append(additions, &CommitOperationAdd{
+			PathInRepo: path.Join(prefix, relPath),
+			LocalPath:  filePath,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking folder %s: %w", localDir, err)
+	}
+	return additions, nil
+}
+
+// folderDeletions returns a delete operation for every remote file under prefix matching the delete patterns
+func folderDeletions(remoteFiles []string, prefix string, deletePatterns []string, additions []*CommitOperationAdd) []CommitOperation {
+	uploaded := make(map[string]bool, len(additions))
+	for _, add := range additions {
+		uploaded[add.PathInRepo] = true
+	}
+
+	deleteGlobs := compileGlobs(deletePatterns)
+	var deletions []CommitOperation
+	for _, remoteFile := range remoteFiles {
+		relPath := remoteFile
+		if prefix != "" {
+			if !strings.HasPrefix(remoteFile, prefix+"/") {
+				continue
+			}
+			relPath = strings.TrimPrefix(remoteFile, prefix+"/")
+		}
+		// Never delete files that are re-uploaded, nor the .gitattributes at the root
+		if uploaded[remoteFile] || remoteFile == ".gitattributes" {
+			continue
+		}
+		if deleteGlobs.matchAny(relPath) {
+			deletions = append(deletions, &CommitOperationDelete{PathInRepo: remoteFile})
+		}
+	}
+	return deletions
+}
+
+// filterPath reports whether a path matches the allow patterns (if any) and none of the ignore patterns
+func filterPath(p string, allowGlobs, ignoreGlobs globs) bool {
+	if len(allowGlobs) > 0 && !allowGlobs.matchAny(p) {
+		return false
+	}
+	return !ignoreGlobs.matchAny(p)
+}
+
+// globs are compiled glob patterns, an invalid pattern is kept as nil and matches nothing
+type globs []*regexp.Regexp
+
+// compileGlobs compiles fnmatch-style glob patterns.
+// A pattern ending with "/" matches everything inside that folder.
+func compileGlobs(patterns []string) globs {
+	compiled := make(globs, 0, len(patterns))
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/") {
+			pattern += "*"
+		}
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			re = nil
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled
+}
+
+// matchAny reports whether a path matches at least one of the patterns
+func (g globs) matchAny(p string) bool {
+	for _, re := range g {
+		if re != nil && re.MatchString(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts an fnmatch-style glob pattern to a regular expression
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
@@ -0,0 +1,301 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// largeFolderMetadataDir holds the upload state of every file, relative to the uploaded folder
+	largeFolderMetadataDir = ".cache/huggingface/upload"
+
+	defaultFilesPerCommit = 100
+)
+
+// UploadLargeFolderOptions holds options for uploading a large folder
+type UploadLargeFolderOptions struct {
+	AllowPatterns  []string // if set, only files matching at least one pattern are uploaded
+	IgnorePatterns []string // files matching any pattern are not uploaded
+	Revision       string   // branch to commit to, defaults to "main"
+	CommitMessage  string   // defaults to "upload large folder (part N)"
+	FilesPerCommit int      // defaults to 100
+	Progress       ProgressReporter
+}
+
+// largeFileState is the upload state of a single file, saved next to the data so that uploads can be resumed
+type largeFileState struct {
+	Size         int64  `json:"size"`
+	ModTime      int64  `json:"mtime"` // nanoseconds
+	Sha256       string `json:"sha256,omitempty"`
+	Sample       []byte `json:"sample,omitempty"`
+	UploadMode   string `json:"upload_mode,omitempty"`
+	ShouldIgnore bool   `json:"should_ignore,omitempty"`
+	IsUploaded   bool   `json:"is_uploaded,omitempty"`
+	IsCommitted  bool   `json:"is_committed,omitempty"`
+}
+
+// largeFile is a file of the folder along with its upload state
+type largeFile struct {
+	op           *CommitOperationAdd
+	state        *largeFileState
+	metadataPath string
+}
+
+// UploadLargeFolder uploads a folder too large for a single commit.
+// Files are processed in rounds of FilesPerCommit files: each round is hashed, pre-uploaded, uploaded to LFS and committed
+// before the next one starts. The state of every file is recorded under .cache/huggingface/upload in the folder, so that
+// calling it again after an interruption resumes where it left off.
+// Files are uploaded at the same path in the repository, and files modified since they were hashed are uploaded again.
+func (c *HubClient) UploadLargeFolder(ctx context.Context, repoId, repoType, localDir string, opts *UploadLargeFolderOptions) ([]*CommitInfo, error) {
+	if err := c.requireAuth("upload large folder"); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &UploadLargeFolderOptions{}
+	}
+	revision := revisionOrDefault(opts.Revision)
+
+	files, err := loadLargeFiles(ctx, localDir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	filesPerCommit := opts.FilesPerCommit
+	if filesPerCommit <= 0 {
+		filesPerCommit = defaultFilesPerCommit
+	}
+	message := opts.CommitMessage
+	if message == "" {
+		message = "upload large folder"
+	}
+
+	// Files are processed in rounds of FilesPerCommit files, every round ending with a commit,
+	// so that uploaded files are committed regularly instead of once the whole folder is uploaded
+	pending := filterLargeFiles(files, func(state *largeFileState) bool {
+		return !state.ShouldIgnore && !state.IsCommitted
+	})
+	var commits []*CommitInfo
+	for _, round := range chunkLargeFiles(pending, filesPerCommit) {
+		commitOpts := &CommitOptions{
+			CommitMessage: fmt.Sprintf("%s (part %d)", message, len(commits)+1),
+			Revision:      opts.Revision,
+			Progress:      opts.Progress,
+		}
+		commitInfo, err := c.uploadLargeFolderRound(ctx, repoId, repoType, revision, round, commitOpts)
+		if err != nil {
+			return commits, err
+		}
+		if commitInfo != nil {
+			commits = append(commits, commitInfo)
+		}
+	}
+
+	return commits, nil
+}
+
+// uploadLargeFolderRound hashes, pre-uploads, uploads and commits a round of files, saving the state of every file
+// after each step. It returns nil if there was nothing to commit (e.g. all files were ignored by the Hub).
+func (c *HubClient) uploadLargeFolderRound(ctx context.Context, repoId, repoType, revision string, files []*largeFile, opts *CommitOptions) (*CommitInfo, error) {
+	// 1. Hash
+	for _, file := range files {
+		if file.state.Sha256 != "" {
+			continue
+		}
+		info, err := newUploadInfo(ctx, file.op, opts.Progress)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.setHash(info); err != nil {
+			return nil, err
+		}
+		if err := file.save(); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range files {
+		file.op.uploadInfo = &uploadInfo{Size: file.state.Size, Sha256: file.state.Sha256, Sample: file.state.Sample}
+	}
+
+	// 2. Pre-upload
+	toPreupload := filterLargeFiles(files, func(state *largeFileState) bool {
+		return state.UploadMode == "" && !state.ShouldIgnore
+	})
+	for _, chunk := range chunkLargeFiles(toPreupload, preuploadBatchSize) {
+		if _, err := c.preUpload(ctx, repoId, repoType, revision, false, largeFileOps(chunk), opts.Progress); err != nil {
+			return nil, err
+		}
+		for _, file := range chunk {
+			file.state.UploadMode = file.op.uploadMode
+			file.state.ShouldIgnore = file.op.shouldIgnore
+			// Regular files are uploaded as part of the commit
+			file.state.IsUploaded = file.op.uploadMode == "regular"
+			if err := file.save(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, file := range files {
+		file.op.uploadMode = file.state.UploadMode
+		file.op.shouldIgnore = file.state.ShouldIgnore
+	}
+
+	// 3. Upload LFS files
+	toUpload := filterLargeFiles(files, func(state *largeFileState) bool {
+		return state.UploadMode == "lfs" && !state.ShouldIgnore && !state.IsUploaded
+	})
+	for _, chunk := range chunkLargeFiles(toUpload, lfsBatchSize) {
+		if err := c.uploadLFSFiles(ctx, repoId, repoType, revision, largeFileOps(chunk), opts.Progress); err != nil {
+			return nil, err
+		}
+		for _, file := range chunk {
+			file.state.IsUploaded = true
+			if err := file.save(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 4. Commit
+	toCommit := filterLargeFiles(files, func(state *largeFileState) bool {
+		return state.IsUploaded && !state.ShouldIgnore && !state.IsCommitted
+	})
+	if len(toCommit) == 0 {
+		return nil, nil
+	}
+	ops := make([]CommitOperation, 0, len(toCommit))
+	for _, file := range toCommit {
+		ops = append(ops, file.op)
+	}
+	commitInfo, err := c.commit(ctx, repoId, repoType, "", opts, ops, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range toCommit {
+		file.state.IsCommitted = true
+		if err := file.save(); err != nil {
+			return nil, err
+		}
+	}
+	return commitInfo, nil
+}
+
+// loadLargeFiles lists the files to upload and loads their saved state, discarding it for files modified since
+func loadLargeFiles(ctx context.Context, localDir string, opts *UploadLargeFolderOptions) ([]*largeFile, error) {
+	additions, err := folderAdditions(ctx, localDir, "", &UploadFolderOptions{
+		AllowPatterns:  opts.AllowPatterns,
+		IgnorePatterns: opts.IgnorePatterns,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*largeFile, 0, len(additions))
+	for _, add := range additions {
+		fileInfo, err := os.Stat(add.LocalPath)
+		if err != nil {
+			return nil, fmt.Errorf("error getting file info: %w", err)
+		}
+
+		file := &largeFile{
+			op:           add,
+			metadataPath: filepath.Join(localDir, filepath.FromSlash(largeFolderMetadataDir), filepath.FromSlash(add.PathInRepo)+".metadata"),
+		}
+		state, err := readLargeFileState(file.metadataPath)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil || state.Size != fileInfo.Size() || state.ModTime != fileInfo.ModTime().UnixNano() {
+			state = &largeFileState{Size: fileInfo.Size(), ModTime: fileInfo.ModTime().UnixNano()}
+		}
+		file.state = state
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// readLargeFileState reads the saved state of a file, or returns nil if there is none
+func readLargeFileState(metadataPath string) (*largeFileState, error) {
+	data, err := os.ReadFile(metadataPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading upload state: %w", err)
+	}
+
+	var state largeFileState
+	if err := json.Unmarshal(data, &state); err != nil {
+		// A corrupted state is discarded, the file is processed again
+		return nil, nil
+	}
+	return &state, nil
+}
+
+// setHash records the hash of the file along with the size that was hashed, which is what gets uploaded.
+// The modification time is read again, so that a file modified while it was hashed is hashed again on the next run.
+func (f *largeFile) setHash(info *uploadInfo) error {
+	fileInfo, err := os.Stat(f.op.LocalPath)
+	if err != nil {
+		return fmt.Errorf("error getting file info: %w", err)
+	}
+	f.state.Size = info.Size
+	f.state.ModTime = fileInfo.ModTime().UnixNano()
+	f.state.Sha256 = info.Sha256
+	f.state.Sample = info.Sample
+	return nil
+}
+
+// save writes the state of the file atomically, so that a crash never leaves a partial state behind
+func (f *largeFile) save() error {
+	data, err := json.Marshal(f.state)
+	if err != nil {
+		return fmt.Errorf("error encoding upload state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(f.metadataPath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+
+	tmpPath := f.metadataPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("error writing upload state: %w", err)
+	}
+	if err := os.Rename(tmpPath, f.metadataPath); err != nil {
+		return fmt.Errorf("error writing upload state: %w", err)
+	}
+	return nil
+}
+
+// filterLargeFiles returns the files whose state matches
+func filterLargeFiles(files []*largeFile, keep func(*largeFileState) bool) []*largeFile {
+	var filtered []*largeFile
+	for _, file := range files {
+		if keep(file.state) {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+// chunkLargeFiles splits the files in chunks of at most size files
+func chunkLargeFiles(files []*largeFile, size int) [][]*largeFile {
+	var chunks [][]*largeFile
+	for start := 0; start < len(files); start += size {
+		chunks = append(chunks, files[start:min(start+size, len(files))])
+	}
+	return chunks
+}
+
+// largeFileOps returns the add operations of the files
+func largeFileOps(files []*largeFile) []*CommitOperationAdd {
+	ops := make([]*CommitOperationAdd, 0, len(files))
+	for _, file := range files {
+		ops = append(ops, file.op)
+	}
+	return ops
+}
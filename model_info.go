@@ -1,6 +1,7 @@
 package huggingface
 
 import (
+	"context"
 	"fmt"
 	"encoding/json"
 	"net/http"
@@ -18,17 +19,17 @@ type ModelInfo struct {
 }
 
 // ModelInfo retrieves information about a specific model repository.
-func (c *HubClient) ModelInfo(repoId string) (*ModelInfo, error) {
+func (c *HubClient) ModelInfo(ctx context.Context, repoId string) (*ModelInfo, error) {
 	path := fmt.Sprintf("%s/api/models/%s", c.BaseURL, repoId)
 
-	resp, err := c.doRequest("GET", path, nil, nil)
+	resp, err := c.doRequest(ctx, "GET", path, nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("model info request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, CreateApiError(resp)
+		return nil, newAPIError(resp, repoId)
 	}
 
 	var modelInfo ModelInfo
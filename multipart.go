@@ -0,0 +1,136 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// multipartPart is a single part of a multipart LFS upload
+type multipartPart struct {
+	Number int
+	URL    string
+	ETag   string
+}
+
+// uploadMultipartLFS uploads the file content in chunks to the presigned part URLs, then completes the upload
+func (c *HubClient) uploadMultipartLFS(ctx context.Context, action *LFSAction, oid string, content io.ReaderAt, size int64, progress *fileProgress) error {
+	chunkSize, parts, err := parseMultipartHeader(action.Header, size)
+	if err != nil {
+		return err
+	}
+
+	if err := c.uploadParts(ctx, parts, chunkSize, content, size, progress); err != nil {
+		return err
+	}
+
+	return c.completeMultipartUpload(ctx, action.Href, oid, parts)
+}
+
+// parseMultipartHeader extracts the chunk size and the part URLs from the header of a multipart upload action.
+// The header holds a "chunk_size" key and one key per part number (e.g. "00001") with its presigned URL.
+func parseMultipartHeader(header map[string]string, size int64) (int64, []*multipartPart, error) {
+	chunkSize, err := strconv.ParseInt(header["chunk_size"], 10, 64)
+	if err != nil || chunkSize <= 0 {
+		return 0, nil, fmt.Errorf("invalid chunk size in multipart upload header: %q", header["chunk_size"])
+	}
+
+	var parts []*multipartPart
+	for key, partURL := range header {
+		if key == "chunk_size" {
+			continue
+		}
+		number, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		parts = append(parts, &multipartPart{Number: number, URL: partURL})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return 0, nil, fmt.Errorf("invalid multipart upload header: missing part %d", i+1)
+		}
+	}
+
+	expectedParts := (size + chunkSize - 1) / chunkSize
+	if int64(len(parts)) != expectedParts {
+		return 0, nil, fmt.Errorf("invalid multipart upload header: got %d parts, expected %d for %d bytes with chunk size %d", len(parts), expectedParts, size, chunkSize)
+	}
+
+	return chunkSize, parts, nil
+}
+
+// uploadParts uploads every part, up to MultipartConcurrency at a time, and records their ETags.
+// Every part reads its own section of the content, so no more than one chunk per worker is in flight.
+func (c *HubClient) uploadParts(ctx context.Context, parts []*multipartPart, chunkSize int64, content io.ReaderAt, size int64, progress *fileProgress) error {
+	return runWorkers(ctx, c.MultipartConcurrency, parts, func(ctx context.Context, part *multipartPart) error {
+		start := int64(part.Number-1) * chunkSize
+		length := min(chunkSize, size-start)
+
+		etag, err := c.uploadPart(ctx, part.URL, content, start, length, progress)
+		if err != nil {
+			return fmt.Errorf("error uploading part %d: %w", part.Number, err)
+		}
+		part.ETag = etag
+		return nil
+	})
+}
+
+// uploadPart streams a single chunk of the content to its presigned URL and returns its ETag. It is retried on transient errors.
+func (c *HubClient) uploadPart(ctx context.Context, partURL string, content io.ReaderAt, start, length int64, progress *fileProgress) (string, error) {
+	body, getBody := rewindableBody(content, start, length, progress)
+	req, err := http.NewRequestWithContext(ctx, "PUT", partURL, body)
+	if err != nil {
+		return "", fmt.Errorf("error creating part upload request: %w", err)
+	}
+	req.ContentLength = length
+	req.GetBody = getBody
+
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return "", fmt.Errorf("part upload request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("part upload failed: %w", newAPIError(resp, ""))
+	}
+
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("part upload response is missing the ETag header")
+	}
+	return etag, nil
+}
+
+// completeMultipartUpload sends the ETags of all parts to the completion URL
+func (c *HubClient) completeMultipartUpload(ctx context.Context, completionURL, oid string, parts []*multipartPart) error {
+	completedParts := make([]map[string]interface{}, 0, len(parts))
+	for _, part := range parts {
+		completedParts = append(completedParts, map[string]interface{}{
+			"partNumber": part.Number,
+			"etag":       part.ETag,
+		})
+	}
+	completionBody := map[string]interface{}{
+		"oid":   oid,
+		"parts": completedParts,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", completionURL, completionBody, lfsHeaders())
+	if err != nil {
+		return fmt.Errorf("multipart completion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("multipart completion failed: %w", newAPIError(resp, ""))
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseMultipartHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    map[string]string
+		size      int64
+		wantParts int
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			header:    map[string]string{"chunk_size": "4", "00001": "u1", "00002": "u2", "00003": "u3"},
+			size:      10,
+			wantParts: 3,
+		},
+		{
+			name:    "missing part",
+			header:  map[string]string{"chunk_size": "4", "00001": "u1", "00003": "u3", "00004": "u4"},
+			size:    16,
+			wantErr: "missing part 2",
+		},
+		{
+			name:    "wrong part count",
+			header:  map[string]string{"chunk_size": "4", "00001": "u1", "00002": "u2"},
+			size:    10,
+			wantErr: "got 2 parts, expected 3",
+		},
+		{
+			name:    "bad chunk size",
+			header:  map[string]string{"chunk_size": "abc", "00001": "u1"},
+			size:    10,
+			wantErr: "invalid chunk size",
+		},
+		{
+			name:    "zero chunk size",
+			header:  map[string]string{"chunk_size": "0", "00001": "u1"},
+			size:    10,
+			wantErr: "invalid chunk size",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunkSize, parts, err := parseMultipartHeader(tt.header, tt.size)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if chunkSize != 4 {
+				t.Errorf("got chunk size %d, want 4", chunkSize)
+			}
+			if len(parts) != tt.wantParts {
+				t.Fatalf("got %d parts, want %d", len(parts), tt.wantParts)
+			}
+			for i, part := range parts {
+				if part.Number != i+1 || part.URL != fmt.Sprintf("u%d", i+1) {
+					t.Errorf("part %d: got %+v", i, part)
+				}
+			}
+		})
+	}
+}
+
+func TestUploadMultipartLFS(t *testing.T) {
+	content := []byte("0123456789")
+
+	var mu sync.Mutex
+	received := map[string]string{}
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("storage got %s, want PUT", r.Method)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("the token was sent to the storage")
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received[r.URL.Path] = string(body)
+		mu.Unlock()
+		w.Header().Set("ETag", "etag"+r.URL.Path)
+	}))
+	defer storage.Close()
+
+	var completion struct {
+		Oid   string `json:"oid"`
+		Parts []struct {
+			PartNumber int    `json:"partNumber"`
+			ETag       string `json:"etag"`
+		} `json:"parts"`
+	}
+	hub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/complete" {
+			t.Errorf("hub got %s %s, want POST /complete", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer hf_test" {
+			t.Errorf("completion got Authorization %q", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&completion); err != nil {
+			t.Errorf("error decoding completion: %v", err)
+		}
+	}))
+	defer hub.Close()
+
+	client := &HubClient{
+		BaseURL:              hub.URL,
+		HTTPClient:           http.DefaultClient,
+		Auth:                 NewAuth(NewStaticTokenProvider("hf_test")),
+		MultipartConcurrency: 2,
+	}
+	action := &LFSAction{
+		Href: hub.URL + "/complete",
+		Header: map[string]string{
+			"chunk_size": "4",
+			"00001":      storage.URL + "/1",
+			"00002":      storage.URL + "/2",
+			"00003":      storage.URL + "/3",
+		},
+	}
+
+	var sent int64
+	progress := newFileProgress(ProgressReporterFunc(func(_ string, _ UploadPhase, bytesSent, _ int64) {
+		mu.Lock()
+		sent = max(sent, bytesSent)
+		mu.Unlock()
+	}), "model.bin", PhaseUploading, int64(len(content)))
+
+	err := client.uploadMultipartLFS(context.Background(), action, "abc", bytes.NewReader(content), int64(len(content)), progress)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantChunks := map[string]string{"/1": "0123", "/2": "4567", "/3": "89"}
+	for path, want := range wantChunks {
+		if received[path] != want {
+			t.Errorf("part %s: got %q, want %q", path, received[path], want)
+		}
+	}
+	if sent != int64(len(content)) {
+		t.Errorf("got %d bytes reported, want %d", sent, len(content))
+	}
+
+	if completion.Oid != "abc" {
+		t.Errorf("got completion oid %q, want abc", completion.Oid)
+	}
+	if len(completion.Parts) != 3 {
+		t.Fatalf("got %d completed parts, want 3", len(completion.Parts))
+	}
+	for i, part := range completion.Parts {
+		if part.PartNumber != i+1 || part.ETag != fmt.Sprintf("etag/%d", i+1) {
+			t.Errorf("completed part %d: got %+v", i, part)
+		}
+	}
+}
+
+func TestUploadMultipartLFSMissingETag(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer storage.Close()
+
+	client := &HubClient{BaseURL: storage.URL, HTTPClient: http.DefaultClient, Auth: NewAnonymousAuth()}
+	action := &LFSAction{
+		Href:   storage.URL + "/complete",
+		Header: map[string]string{"chunk_size": "4", "00001": storage.URL + "/1"},
+	}
+
+	err := client.uploadMultipartLFS(context.Background(), action, "abc", strings.NewReader("0123"), 4, nil)
+	if err == nil || !strings.Contains(err.Error(), "missing the ETag header") {
+		t.Fatalf("got error %v, want missing ETag", err)
+	}
+}
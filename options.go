@@ -0,0 +1,137 @@
+package huggingface
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout   = 200 * time.Second
+	defaultUserAgent = "huggingface-go"
+)
+
+// ClientOption configures a HubClient created with NewHubClient.
+type ClientOption func(*clientConfig)
+
+// clientConfig collects the options before the client is built
+type clientConfig struct {
+	endpoint    string
+	provider    TokenProvider
+	profile     string
+	httpClient  *http.Client
+	transport   http.RoundTripper
+	timeout     *time.Duration
+	userAgent   string
+	retryPolicy RetryPolicy
+}
+
+// WithEndpoint sets the URL of the Hub, e.g. an on-prem deployment or a mirror. It overrides HF_ENDPOINT.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.endpoint = endpoint
+	}
+}
+
+// WithToken sets the token used to authenticate. It overrides the profile, HF_TOKEN and the token files.
+func WithToken(token string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.provider = NewStaticTokenProvider(token)
+	}
+}
+
+// WithProfile uses the token saved under that name by the huggingface CLI, in $HF_HOME/stored_tokens.
+func WithProfile(profile string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.profile = profile
+	}
+}
+
+// WithAnonymous creates a client without token, even if HF_TOKEN is set. Only public repositories can be read.
+func WithAnonymous() ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.provider = staticToken("")
+	}
+}
+
+// WithTokenProvider asks the provider for the token before every request, e.g. to pick up rotated tokens.
+// It overrides the profile, HF_TOKEN and the token files.
+func WithTokenProvider(provider TokenProvider) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.provider = provider
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for all requests. The client is copied, never modified:
+// its timeout is kept unless WithTimeout is passed too.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the RoundTripper of the HTTP client, e.g. to add tracing or a proxy.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.transport = transport
+	}
+}
+
+// WithTimeout sets the timeout of every HTTP request, including reading the response body. Zero means no timeout.
+// It defaults to 200 seconds, or to the timeout of the client passed to WithHTTPClient.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.timeout = &timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent to the Hub.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.userAgent = userAgent
+	}
+}
+
+// WithRetryPolicy sets how requests failing with a transient error are retried.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(cfg *clientConfig) {
+		cfg.retryPolicy = policy
+	}
+}
+
+// resolveAuth uses the token set explicitly, then the one of the profile, then the one of the environment
+func (cfg *clientConfig) resolveAuth() (*Auth, error) {
+	if cfg.provider != nil {
+		return NewAuth(cfg.provider), nil
+	}
+	if cfg.profile != "" {
+		return NewAuthFromProfile(cfg.profile)
+	}
+	token, err := resolveToken()
+	if err != nil {
+		return nil, err
+	}
+	return NewAuth(NewStaticTokenProvider(token)), nil
+}
+
+// buildHTTPClient returns the HTTP client configured by the options
+func (cfg *clientConfig) buildHTTPClient() *http.Client {
+	httpClient := &http.Client{Timeout: defaultTimeout}
+	if cfg.httpClient != nil {
+		clientCopy := *cfg.httpClient
+		httpClient = &clientCopy
+	}
+	if cfg.timeout != nil {
+		httpClient.Timeout = *cfg.timeout
+	}
+	if cfg.transport != nil {
+		httpClient.Transport = cfg.transport
+	}
+	return httpClient
+}
+
+func (c *HubClient) userAgent() string {
+	if c.UserAgent == "" {
+		return defaultUserAgent
+	}
+	return c.UserAgent
+}
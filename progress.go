@@ -0,0 +1,94 @@
+package huggingface
+
+import (
+	"io"
+	"sync/atomic"
+)
+
+// UploadPhase is a step of an upload reported to a ProgressReporter.
+type UploadPhase string
+
+const (
+	PhaseHashing    UploadPhase = "hashing"    // computing the SHA256 hash of a file
+	PhasePreupload  UploadPhase = "preupload"  // asking the Hub how the file should be uploaded
+	PhaseUploading  UploadPhase = "uploading"  // sending an LFS file to the storage
+	PhaseCommitting UploadPhase = "committing" // sending the commit, including the content of regular files
+)
+
+// ProgressReporter receives progress updates during uploads.
+// It is called from several goroutines at once when files or parts are uploaded in parallel.
+type ProgressReporter interface {
+	ReportProgress(pathInRepo string, phase UploadPhase, bytesSent, totalBytes int64)
+}
+
+// ProgressReporterFunc adapts a function to the ProgressReporter interface.
+type ProgressReporterFunc func(pathInRepo string, phase UploadPhase, bytesSent, totalBytes int64)
+
+// ReportProgress calls f.
+func (f ProgressReporterFunc) ReportProgress(pathInRepo string, phase UploadPhase, bytesSent, totalBytes int64) {
+	f(pathInRepo, phase, bytesSent, totalBytes)
+}
+
+// fileProgress tracks the bytes processed for one file in one phase, it is safe for concurrent use.
+// A nil *fileProgress reports nothing.
+type fileProgress struct {
+	reporter ProgressReporter
+	path     string
+	phase    UploadPhase
+	total    int64
+	sent     atomic.Int64
+}
+
+// newFileProgress returns a tracker for the file, or nil if there is no reporter
+func newFileProgress(reporter ProgressReporter, path string, phase UploadPhase, total int64) *fileProgress {
+	if reporter == nil {
+		return nil
+	}
+	return &fileProgress{reporter: reporter, path: path, phase: phase, total: total}
+}
+
+// start reports that the phase began
+func (p *fileProgress) start() {
+	if p == nil {
+		return
+	}
+	p.reporter.ReportProgress(p.path, p.phase, 0, p.total)
+}
+
+// done reports that the phase completed
+func (p *fileProgress) done() {
+	if p == nil {
+		return
+	}
+	p.reporter.ReportProgress(p.path, p.phase, p.total, p.total)
+}
+
+// add reports n more bytes processed
+func (p *fileProgress) add(n int64) {
+	if p == nil || n == 0 {
+		return
+	}
+	p.reporter.ReportProgress(p.path, p.phase, p.sent.Add(n), p.total)
+}
+
+// reader wraps r so that every read is reported
+func (p *fileProgress) reader(r io.Reader) io.Reader {
+	if p == nil {
+		return r
+	}
+	return &progressReader{r: r, progress: p}
+}
+
+// progressReader reports the bytes read from the underlying reader
+type progressReader struct {
+	r        io.Reader
+	progress *fileProgress
+	read     int64
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.read += int64(n)
+	r.progress.add(int64(n))
+	return n, err
+}
@@ -1,8 +1,10 @@
 package huggingface
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -20,7 +22,10 @@ type CreateRepoResponse struct {
 }
 
 // CreateRepo creates a new repository on Hugging Face Hub
-func (c *HubClient) CreateRepo(repoId, repoType string, opts *CreateRepoOptions) (*CreateRepoResponse, error) {
+func (c *HubClient) CreateRepo(ctx context.Context, repoId, repoType string, opts *CreateRepoOptions) (*CreateRepoResponse, error) {
+	if err := c.requireAuth("create repo"); err != nil {
+		return nil, err
+	}
 	var respData CreateRepoResponse
 
 	// Validate and split the repoId into namespace and repoName
@@ -33,12 +38,12 @@ func (c *HubClient) CreateRepo(repoId, repoType string, opts *CreateRepoOptions)
 	payload := buildPayload(namespace, repoName, repoType, opts)
 
 	// Send the request
-	resp, err := c.doRequest("POST", "/api/repos/create", payload, nil)
+	resp, err := c.doRequest(ctx, "POST", "/api/repos/create", payload, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	err = parseResponse(resp, &respData)
+	err = parseResponse(resp, repoId, &respData)
 	if err != nil {
 		// Ignore conflict if ExistsOK is true
 		if opts != nil && opts.ExistsOK && resp != nil && resp.StatusCode == http.StatusConflict {
@@ -75,6 +80,30 @@ func buildPayload(namespace, repoName, repoType string, opts *CreateRepoOptions)
 	return payload
 }
 
+// repoTypeURLPrefix returns the URL prefix of a repository type, e.g. "datasets/" (models have none)
+func repoTypeURLPrefix(repoType string) string {
+	if repoType == "" || repoType == "model" {
+		return ""
+	}
+	return repoType + "s/"
+}
+
+// defaultRevision is the branch used when no revision is given
+const defaultRevision = "main"
+
+// revisionOrDefault returns the revision, or the default branch if it is empty
+func revisionOrDefault(revision string) string {
+	if revision == "" {
+		return defaultRevision
+	}
+	return revision
+}
+
+// escapeRevision escapes a revision (branch, tag or commit) so that names like "refs/pr/3" stay a single URL path segment
+func escapeRevision(revision string) string {
+	return url.PathEscape(revisionOrDefault(revision))
+}
+
 // parseRepoID validates and splits the repository ID into namespace and repoName
 func parseRepoID(repoId string) (string, string, error) {
 	parts := strings.Split(repoId, "/")
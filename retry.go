@@ -0,0 +1,190 @@
+package huggingface
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMaxServerDelay = 2 * time.Minute
+)
+
+// Clock tells the time and waits, it can be replaced to test retries without waiting.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock of the time package
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RetryPolicy configures how requests failing with a transient error are sent again.
+// Idempotent requests (GET, HEAD, PUT, DELETE) and LFS uploads are retried on network errors and on 408, 429, 500,
+// 502, 503 and 504 responses. Other requests are only retried on 429, which the Hub returns before processing them.
+// The zero value uses the defaults.
+type RetryPolicy struct {
+	MaxAttempts    int           // attempts per request including the first one, defaults to 5. Set to 1 to disable retries
+	InitialBackoff time.Duration // wait before the first retry, doubled on every retry. Defaults to 1 second
+	MaxBackoff     time.Duration // longest backoff between two attempts, defaults to 30 seconds
+	MaxServerDelay time.Duration // longest wait asked by the Hub in Retry-After or RateLimit headers that is honored, defaults to 2 minutes
+	Clock          Clock         // defaults to the system clock
+}
+
+// withDefaults returns the policy with the defaults set
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultMaxBackoff
+	}
+	if p.MaxServerDelay <= 0 {
+		p.MaxServerDelay = defaultMaxServerDelay
+	}
+	if p.Clock == nil {
+		p.Clock = systemClock{}
+	}
+	return p
+}
+
+// backoff returns how long to wait after a failed attempt, 1 for the first one.
+// The wait asked by the Hub in Retry-After or RateLimit headers is used when there is one, up to MaxServerDelay,
+// with exponential backoff and jitter otherwise.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := serverDelay(resp, p.Clock.Now()); ok {
+			return min(delay, p.MaxServerDelay)
+		}
+	}
+
+	delay := p.InitialBackoff << (attempt - 1)
+	if delay <= 0 || delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	// Wait between half and all of the delay, so that clients failing together do not retry together
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// rateLimitResetPattern extracts the seconds until the quota resets from a RateLimit header, e.g. `"api";r=0;t=55`
+var rateLimitResetPattern = regexp.MustCompile(`(?:^|;)\s*t=(\d+)`)
+
+// serverDelay returns the wait asked by the Hub in the Retry-After header, or in the RateLimit headers of a 429
+func serverDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if match := rateLimitResetPattern.FindStringSubmatch(resp.Header.Get("RateLimit")); match != nil {
+		if seconds, err := strconv.Atoi(match[1]); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("RateLimit-Reset")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	return 0, false
+}
+
+// isIdempotent reports whether sending the request twice has the same effect as sending it once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether an attempt failed with a transient error worth retrying
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Cancelled requests are never retried, network errors only when the request is idempotent
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// doWithRetry sends the request, and sends it again per the retry policy while it fails with a transient error.
+// The body is rewound with GetBody, requests whose body cannot be rewound are sent once.
+func (c *HubClient) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy.withDefaults()
+	ctx := req.Context()
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if attempt >= policy.MaxAttempts || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		delay := policy.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body so that the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-policy.Clock.After(delay):
+		}
+
+		req = req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// rewindableBody returns a body reading content[start:start+length] and reporting the bytes read to progress, along with
+// a GetBody function rewinding it for retries. The bytes sent by a failed attempt are removed from the progress.
+func rewindableBody(content io.ReaderAt, start, length int64, progress *fileProgress) (io.Reader, func() (io.ReadCloser, error)) {
+	var current io.Reader
+	newBody := func() io.Reader {
+		if previous, ok := current.(*progressReader); ok {
+			progress.add(-previous.read)
+		}
+		current = progress.reader(io.NewSectionReader(content, start, length))
+		return current
+	}
+
+	body := newBody()
+	getBody := func() (io.ReadCloser, error) {
+		return io.NopCloser(newBody()), nil
+	}
+	return body, getBody
+}
@@ -0,0 +1,282 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock records the waits instead of sleeping
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.waits = append(c.waits, d)
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, policy RetryPolicy) (*HubClient, *fakeClock) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	policy.Clock = clock
+	client := &HubClient{
+		BaseURL:     server.URL,
+		HTTPClient:  server.Client(),
+		Auth:        NewAnonymousAuth(),
+		RetryPolicy: policy,
+	}
+	return client, clock
+}
+
+func TestServerDelay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{
+			name:      "Retry-After in seconds",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": {"3"}},
+			wantDelay: 3 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "Retry-After as a date",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": {now.Add(10 * time.Second).Format(http.TimeFormat)}},
+			wantDelay: 10 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "Retry-After in the past",
+			status:    http.StatusServiceUnavailable,
+			header:    http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}},
+			wantDelay: 0,
+			wantOK:    true,
+		},
+		{
+			name:      "RateLimit reset",
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{"Ratelimit": {`"api";r=0;t=55`}},
+			wantDelay: 55 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:      "RateLimit-Reset",
+			status:    http.StatusTooManyRequests,
+			header:    http.Header{"Ratelimit-Reset": {"7"}},
+			wantDelay: 7 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:   "RateLimit ignored when not rate limited",
+			status: http.StatusServiceUnavailable,
+			header: http.Header{"Ratelimit": {`"api";r=0;t=55`}},
+			wantOK: false,
+		},
+		{
+			name:   "no header",
+			status: http.StatusBadGateway,
+			header: http.Header{},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: tt.header}
+			delay, ok := serverDelay(resp, now)
+			if ok != tt.wantOK || delay != tt.wantDelay {
+				t.Errorf("got (%v, %v), want (%v, %v)", delay, ok, tt.wantDelay, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestBackoffIsCapped(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second, Clock: &fakeClock{}}.withDefaults()
+
+	for attempt := 1; attempt <= 70; attempt++ {
+		delay := policy.backoff(attempt, nil)
+		want := min(time.Second<<min(attempt-1, 3), 4*time.Second)
+		if delay < want/2 || delay > want {
+			t.Errorf("attempt %d: got %v, want between %v and %v", attempt, delay, want/2, want)
+		}
+	}
+}
+
+func TestServerDelayIsCapped(t *testing.T) {
+	policy := RetryPolicy{MaxServerDelay: time.Minute, Clock: &fakeClock{}}.withDefaults()
+
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": {"3600"}}}
+	if delay := policy.backoff(1, resp); delay != time.Minute {
+		t.Errorf("got %v, want 1m0s", delay)
+	}
+	resp.Header.Set("Retry-After", "20")
+	if delay := policy.backoff(1, resp); delay != 20*time.Second {
+		t.Errorf("got %v, want 20s", delay)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	client, clock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, RetryPolicy{})
+
+	resp, err := client.doRequest(context.Background(), "GET", "/api/models/a/b", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || attempts != 3 {
+		t.Errorf("got status %d after %d attempts, want 200 after 3", resp.StatusCode, attempts)
+	}
+	if len(clock.waits) != 2 || clock.waits[0] != 2*time.Second || clock.waits[1] != 2*time.Second {
+		t.Errorf("got waits %v, want [2s 2s]", clock.waits)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	client, clock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}, RetryPolicy{MaxAttempts: 3})
+
+	resp, err := client.doRequest(context.Background(), "GET", "/api/models/a/b", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadGateway || attempts != 3 || len(clock.waits) != 2 {
+		t.Errorf("got status %d after %d attempts and %d waits, want 502 after 3 attempts and 2 waits", resp.StatusCode, attempts, len(clock.waits))
+	}
+}
+
+func TestRetryNotOnPOST502(t *testing.T) {
+	attempts := 0
+	client, clock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}, RetryPolicy{})
+
+	resp, err := client.doRequest(context.Background(), "POST", "/api/models/a/b/commit/main", map[string]string{"a": "b"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if attempts != 1 || len(clock.waits) != 0 {
+		t.Errorf("got %d attempts and waits %v, want a single attempt", attempts, clock.waits)
+	}
+}
+
+func TestRetryPOSTOn429(t *testing.T) {
+	var bodies []string
+	client, clock := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("RateLimit", `"api";r=0;t=30`)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, RetryPolicy{})
+
+	resp, err := client.doRequest(context.Background(), "POST", "/api/models/a/b/preupload/main", map[string]string{"a": "b"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("got bodies %q, want the same body twice", bodies)
+	}
+	if len(clock.waits) != 1 || clock.waits[0] != 30*time.Second {
+		t.Errorf("got waits %v, want [30s]", clock.waits)
+	}
+}
+
+func TestRetryRewindsUploadBody(t *testing.T) {
+	content := []byte("0123456789")
+	var bodies [][]byte
+	client, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}, RetryPolicy{})
+
+	var reported []int64
+	progress := newFileProgress(ProgressReporterFunc(func(_ string, _ UploadPhase, bytesSent, _ int64) {
+		reported = append(reported, bytesSent)
+	}), "model.bin", PhaseUploading, int64(len(content)))
+
+	err := client.uploadFileToLFS(context.Background(), client.BaseURL+"/lfs/abc", bytes.NewReader(content), int64(len(content)), progress)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 || !bytes.Equal(bodies[0], content) || !bytes.Equal(bodies[1], content) {
+		t.Fatalf("got bodies %q, want the content twice", bodies)
+	}
+	for _, sent := range reported {
+		if sent > int64(len(content)) {
+			t.Errorf("reported %d bytes sent for %d bytes of content", sent, len(content))
+		}
+	}
+	if last := reported[len(reported)-1]; last != int64(len(content)) {
+		t.Errorf("got %d bytes sent in the end, want %d", last, len(content))
+	}
+}
+
+func TestRetryStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	client, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, RetryPolicy{})
+
+	_, err := client.doRequest(ctx, "GET", "/api/models/a/b", nil, nil)
+	if err == nil || attempts != 1 {
+		t.Errorf("got error %v after %d attempts, want the context error after 1", err, attempts)
+	}
+}
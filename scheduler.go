@@ -0,0 +1,182 @@
+package huggingface
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCommitEvery = 5 * time.Minute
+
+// CommitSchedulerOptions holds options for a CommitScheduler
+type CommitSchedulerOptions struct {
+	Every          time.Duration // time between two pushes, defaults to 5 minutes
+	PathInRepo     string        // folder in the repository to upload to, defaults to the root
+	AllowPatterns  []string      // if set, only files matching at least one pattern are uploaded
+	IgnorePatterns []string      // files matching any pattern are not uploaded
+	Revision       string        // branch to commit to, defaults to "main"
+	CommitMessage  string        // defaults to "scheduled commit"
+}
+
+// CommitScheduler periodically pushes the files of a local folder that changed since the last push, in the background.
+// Create it with NewCommitScheduler and always call Stop once done.
+type CommitScheduler struct {
+	client   *HubClient
+	repoId   string
+	repoType string
+	localDir string
+	opts     CommitSchedulerOptions
+
+	trigger  chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	errs     chan error
+	stopOnce sync.Once
+	stopErr  error
+
+	// ctx is cancelled when Stop gives up waiting, to abort the push in flight
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// uploaded holds the size and modification time of every file at the time it was pushed
+	uploaded map[string]fileStamp
+}
+
+// fileStamp identifies a version of a local file
+type fileStamp struct {
+	size    int64
+	modTime int64
+}
+
+// NewCommitScheduler starts a goroutine pushing the changes of localDir to the repository every opts.Every.
+func (c *HubClient) NewCommitScheduler(repoId, repoType, localDir string, opts *CommitSchedulerOptions) *CommitScheduler {
+	s := &CommitScheduler{
+		client:   c,
+		repoId:   repoId,
+		repoType: repoType,
+		localDir: localDir,
+		trigger:  make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		errs:     make(chan error, 16),
+		uploaded: map[string]fileStamp{},
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	if opts != nil {
+		s.opts = *opts
+	}
+	if s.opts.Every <= 0 {
+		s.opts.Every = defaultCommitEvery
+	}
+	if s.opts.CommitMessage == "" {
+		s.opts.CommitMessage = "scheduled commit"
+	}
+
+	go s.run()
+	return s
+}
+
+// Trigger asks for a push as soon as possible, without waiting for the next tick. It never blocks.
+func (s *CommitScheduler) Trigger() {
+	select {
+	case s.trigger <- struct{}{}:
+	default: // a push is already pending
+	}
+}
+
+// Errors returns the channel on which failed pushes are reported. Files of a failed push are retried on the next one.
+// Errors are dropped when nobody reads them and the channel is full. The channel is closed once the scheduler stopped.
+func (s *CommitScheduler) Errors() <-chan error {
+	return s.errs
+}
+
+// Stop stops the scheduler after a final push of the pending changes, and returns the error of that push.
+// If ctx is done first, Stop cancels the push in flight and returns the error of ctx right away, without waiting for
+// the push to wind down; the Errors channel is closed once it did.
+func (s *CommitScheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	select {
+	case <-s.done:
+		s.cancel()
+		return s.stopErr
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// run pushes the changes on every tick or trigger, until stopped
+func (s *CommitScheduler) run() {
+	defer close(s.done)
+	defer close(s.errs)
+
+	ticker := time.NewTicker(s.opts.Every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.trigger:
+		case <-s.stop:
+			// Final flush
+			s.stopErr = s.push()
+			return
+		}
+
+		if err := s.push(); err != nil {
+			select {
+			case s.errs <- err:
+			default:
+			}
+		}
+	}
+}
+
+// push commits the files that changed since the last successful push
+func (s *CommitScheduler) push() error {
+	prefix := strings.Trim(s.opts.PathInRepo, "/")
+	additions, err := folderAdditions(s.ctx, s.localDir, prefix, &UploadFolderOptions{
+		AllowPatterns:  s.opts.AllowPatterns,
+		IgnorePatterns: s.opts.IgnorePatterns,
+	})
+	if err != nil {
+		return err
+	}
+
+	var ops []CommitOperation
+	stamps := map[string]fileStamp{}
+	for _, add := range additions {
+		fileInfo, err := os.Stat(add.LocalPath)
+		if err != nil {
+			// The file was removed since the folder was listed
+			continue
+		}
+		stamp := fileStamp{size: fileInfo.Size(), modTime: fileInfo.ModTime().UnixNano()}
+		if previous, ok := s.uploaded[add.PathInRepo]; ok && previous == stamp {
+			continue
+		}
+		ops = append(ops, add)
+		stamps[add.PathInRepo] = stamp
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	_, err = s.client.CreateCommit(s.ctx, s.repoId, s.repoType, ops, &CommitOptions{
+		CommitMessage: s.opts.CommitMessage,
+		Revision:      s.opts.Revision,
+	})
+	if err != nil {
+		return fmt.Errorf("scheduled commit failed: %w", err)
+	}
+
+	for path, stamp := range stamps {
+		s.uploaded[path] = stamp
+	}
+	return nil
+}
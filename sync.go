@@ -0,0 +1,127 @@
+package huggingface
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SyncReport describes what SyncFolder did to each file.
+type SyncReport struct {
+	Added     []string    // files that did not exist in the repository
+	Modified  []string    // files whose content changed
+	Unchanged []string    // files skipped because their content is identical
+	Deleted   []string    // remote files deleted because they matched the delete patterns
+	Commit    *CommitInfo // nil if nothing changed
+}
+
+// SyncFolder uploads the content of a local folder like UploadFolder, but only commits files that differ from the repository.
+// Local files are compared to the remote tree: the SHA256 hash for LFS files, the git blob SHA-1 for regular files.
+func (c *HubClient) SyncFolder(ctx context.Context, repoId, repoType, localDir string, opts *UploadFolderOptions) (*SyncReport, error) {
+	// Fail before hashing the whole folder
+	if err := c.requireAuth("sync folder"); err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &UploadFolderOptions{}
+	}
+	prefix := strings.Trim(opts.PathInRepo, "/")
+
+	additions, err := folderAdditions(ctx, localDir, prefix, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := c.ListRepoTree(ctx, repoId, repoType, opts.Revision)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles := make(map[string]PathInfo, len(tree))
+	var remotePaths []string
+	for _, entry := range tree {
+		if entry.Type == "file" {
+			remoteFiles[entry.Path] = entry
+			remotePaths = append(remotePaths, entry.Path)
+		}
+	}
+
+	report := &SyncReport{}
+	var ops []CommitOperation
+	// SHA256 hashes computed for the comparison, so that files are not hashed again when committed
+	hashes := map[*CommitOperationAdd]*uploadInfo{}
+	if len(opts.DeletePatterns) > 0 {
+		for _, op := range folderDeletions(remotePaths, prefix, opts.DeletePatterns, additions) {
+			ops = append(ops, op)
+			report.Deleted = append(report.Deleted, op.pathInRepo())
+		}
+	}
+
+	for _, add := range additions {
+		remote, ok := remoteFiles[add.PathInRepo]
+		if !ok {
+			ops = append(ops, add)
+			report.Added = append(report.Added, add.PathInRepo)
+			continue
+		}
+
+		unchanged, info, err := add.matchesRemote(ctx, remote, opts.Progress)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			hashes[add] = info
+		}
+		if unchanged {
+			report.Unchanged = append(report.Unchanged, add.PathInRepo)
+			continue
+		}
+		ops = append(ops, add)
+		report.Modified = append(report.Modified, add.PathInRepo)
+	}
+
+	if len(ops) == 0 {
+		return report, nil
+	}
+
+	report.Commit, err = c.createCommit(ctx, repoId, repoType, ops, opts.commitOptions(), hashes)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// matchesRemote reports whether the content to upload is identical to the remote file.
+// The upload info computed to compare LFS files is returned, or nil for regular files.
+func (op *CommitOperationAdd) matchesRemote(ctx context.Context, remote PathInfo, reporter ProgressReporter) (bool, *uploadInfo, error) {
+	if remote.Lfs != nil {
+		info, err := newUploadInfo(ctx, op, reporter)
+		if err != nil {
+			return false, nil, err
+		}
+		return info.Size == remote.Lfs.Size && info.Sha256 == remote.Lfs.Oid, info, nil
+	}
+
+	oid, err := op.gitBlobSHA1(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	return oid == remote.Oid, nil, nil
+}
+
+// gitBlobSHA1 computes the git object id of the content, i.e. the SHA-1 of "blob <size>\x00<content>"
+func (op *CommitOperationAdd) gitBlobSHA1(ctx context.Context) (string, error) {
+	content, size, closer, err := op.openContent()
+	if err != nil {
+		return "", err
+	}
+	defer closer.Close()
+
+	hasher := sha1.New()
+	fmt.Fprintf(hasher, "blob %d\x00", size)
+	if _, err := io.Copy(hasher, contextReader(ctx, io.NewSectionReader(content, 0, size))); err != nil {
+		return "", fmt.Errorf("error calculating git blob hash: %w", err)
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
+}
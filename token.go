@@ -0,0 +1,130 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider returns the token used to authenticate requests to the Hub.
+// It is called before every request with the context of the request, from several goroutines at once when uploading
+// in parallel. An empty token sends the request without authentication.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticToken always returns the same token
+type staticToken string
+
+// NewStaticTokenProvider returns a TokenProvider always returning token.
+func NewStaticTokenProvider(token string) TokenProvider {
+	return staticToken(token)
+}
+
+func (t staticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// envToken reads an environment variable on every request
+type envToken string
+
+// NewEnvTokenProvider returns a TokenProvider reading the environment variable name on every request.
+func NewEnvTokenProvider(name string) TokenProvider {
+	return envToken(name)
+}
+
+func (t envToken) Token(ctx context.Context) (string, error) {
+	return strings.TrimSpace(os.Getenv(string(t))), nil
+}
+
+// fileToken reads a token file, again whenever it is modified
+type fileToken struct {
+	path string
+
+	mu      sync.Mutex
+	size    int64
+	modTime time.Time
+	token   string
+}
+
+// NewFileTokenProvider returns a TokenProvider reading the token from a file, e.g. mounted from a secret.
+// The file is read again whenever its size or modification time changes, so rotated tokens are picked up.
+func NewFileTokenProvider(path string) TokenProvider {
+	return &fileToken{path: path}
+}
+
+func (t *fileToken) Token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fileInfo, err := os.Stat(t.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	if fileInfo.Size() == t.size && fileInfo.ModTime().Equal(t.modTime) {
+		return t.token, nil
+	}
+
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading token file: %w", err)
+	}
+	t.token = strings.TrimSpace(string(data))
+	t.size = fileInfo.Size()
+	t.modTime = fileInfo.ModTime()
+	return t.token, nil
+}
+
+// commandToken runs a command printing the token, and caches it
+type commandToken struct {
+	name string
+	args []string
+	ttl  time.Duration
+
+	// sem lets one call run the command at a time, while the others can still give up when their context is done
+	sem       chan struct{}
+	token     string
+	expiresAt time.Time
+}
+
+// NewCommandTokenProvider returns a TokenProvider running a command that prints the token on its standard output,
+// e.g. a secrets manager CLI. The token is cached for ttl, the command runs on every request when ttl is 0.
+// The command is killed when the context of the request is done.
+func NewCommandTokenProvider(ttl time.Duration, name string, args ...string) TokenProvider {
+	return &commandToken{name: name, args: args, ttl: ttl, sem: make(chan struct{}, 1)}
+}
+
+func (t *commandToken) Token(ctx context.Context) (string, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	defer func() { <-t.sem }()
+
+	if t.ttl > 0 && time.Now().Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.name, t.args...)
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("token command %s: %w", t.name, ctxErr)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("token command %s failed: %w: %s", t.name, err, message)
+		}
+		return "", fmt.Errorf("token command %s failed: %w", t.name, err)
+	}
+	t.token = strings.TrimSpace(string(output))
+	t.expiresAt = time.Now().Add(t.ttl)
+	return t.token, nil
+}
@@ -0,0 +1,104 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// PathInfo represents a file or folder in a repository.
+type PathInfo struct {
+	Type string   `json:"type"` // "file" or "directory"
+	Oid  string   `json:"oid"`
+	Size int64    `json:"size"`
+	Path string   `json:"path"`
+	Lfs  *LfsInfo `json:"lfs,omitempty"`
+}
+
+// LfsInfo holds the LFS pointer information of a file.
+type LfsInfo struct {
+	Oid         string `json:"oid"`
+	Size        int64  `json:"size"`
+	PointerSize int64  `json:"pointerSize"`
+}
+
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// ListRepoTree lists all files and folders of a repository recursively at the given revision ("main" if empty).
+func (c *HubClient) ListRepoTree(ctx context.Context, repoId, repoType, revision string) ([]PathInfo, error) {
+	treeURL := fmt.Sprintf("/api/%ss/%s/tree/%s?recursive=true", repoType, repoId, escapeRevision(revision))
+
+	var entries []PathInfo
+	for treeURL != "" {
+		resp, err := c.doRequest(ctx, "GET", treeURL, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("list repo tree request failed: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := newAPIError(resp, repoId)
+			resp.Body.Close()
+			return nil, err
+		}
+
+		var page []PathInfo
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding repo tree: %w", err)
+		}
+		entries = append(entries, page...)
+
+		// Follow the pagination link, if any
+		treeURL = ""
+		if match := nextLinkPattern.FindStringSubmatch(resp.Header.Get("Link")); match != nil {
+			treeURL = match[1]
+		}
+	}
+
+	return entries, nil
+}
+
+// ListRepoFiles lists the paths of all files in a repository at the given revision ("main" if empty).
+func (c *HubClient) ListRepoFiles(ctx context.Context, repoId, repoType, revision string) ([]string, error) {
+	entries, err := c.ListRepoTree(ctx, repoId, repoType, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.Type == "file" {
+			files = append(files, entry.Path)
+		}
+	}
+	return files, nil
+}
+
+// pathsInfo retrieves information about specific paths of a repository at a given revision
+func (c *HubClient) pathsInfo(ctx context.Context, repoId, repoType, revision string, paths []string) ([]PathInfo, error) {
+	body := map[string]interface{}{
+		"paths":  paths,
+		"expand": false,
+	}
+	pathsInfoURL := fmt.Sprintf("/api/%ss/%s/paths-info/%s", repoType, repoId, escapeRevision(revision))
+
+	resp, err := c.doRequest(ctx, "POST", pathsInfoURL, body, nil)
+	if err != nil {
+		return nil, fmt.Errorf("paths info request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, repoId)
+	}
+
+	var infos []PathInfo
+	if err := json.NewDecoder(resp.Body).Decode(&infos); err != nil {
+		return nil, fmt.Errorf("error decoding paths info: %w", err)
+	}
+
+	return infos, nil
+}
@@ -2,99 +2,180 @@ package huggingface
 
 import (
 	"bytes"
-	"crypto/sha256"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
-	"os"
 	"path/filepath"
+	"strings"
 )
 
+// UploadFileOptions holds options for uploading a file
+type UploadFileOptions struct {
+	PathInRepo        string // defaults to the base name of the local file
+	CommitMessage     string
+	CommitDescription string
+	Revision          string // branch to commit to, defaults to "main"
+	CreatePR          bool   // open a pull request against Revision instead of committing to it directly
+	ParentCommit      string // if set, the commit fails with ErrCommitConflict unless it is the head of Revision
+	Progress          ProgressReporter
+}
+
 // UploadFile uploads a file to the specified repository
-func (c *HubClient) UploadFile(repoId, repoType, filePath string) error {
-	file, fileSize, fileName, err := openFile(filePath)
-	if err != nil {
-		return err
+func (c *HubClient) UploadFile(ctx context.Context, repoId, repoType, filePath string, opts *UploadFileOptions) (*CommitInfo, error) {
+	if opts == nil {
+		opts = &UploadFileOptions{}
 	}
-	defer file.Close()
 
-	// 1. Pre-upload (determine if regular or LFS)
-	sample, _ := getFileSample(filePath)
-	preuploadData, err := c.preUpload(repoId, repoType, fileName, fileSize, sample)
-	if err != nil {
-		return err
+	pathInRepo := strings.TrimPrefix(filepath.ToSlash(opts.PathInRepo), "/")
+	if pathInRepo == "" {
+		pathInRepo = filepath.Base(filePath)
+	}
+	op := &CommitOperationAdd{
+		PathInRepo: pathInRepo,
+		LocalPath:  filePath,
 	}
+	return c.uploadOperation(ctx, repoId, repoType, op, opts)
+}
 
-	// 2. Check if file should be ignored
-	if preuploadData.Files[0].ShouldIgnore {
-		fmt.Printf("Skipping file: %s (marked as shouldIgnore)\n", fileName)
-		return nil
+// UploadReader uploads size bytes read from r to pathInRepo.
+// The content is read twice (hashing, then upload) and may be read concurrently for multipart uploads.
+// opts.PathInRepo is ignored.
+func (c *HubClient) UploadReader(ctx context.Context, repoId, repoType, pathInRepo string, r io.ReaderAt, size int64, opts *UploadFileOptions) (*CommitInfo, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader must not be nil")
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+	if opts == nil {
+		opts = &UploadFileOptions{}
 	}
 
-	// 3. Upload or Commit the file based on the mode
-	switch preuploadData.Files[0].UploadMode {
-	case "lfs":
-		if err := c.uploadLFS(repoId, repoType, fileName, file); err != nil {
-			return err
-		}
-		return c.commitFile(repoId, repoType, preuploadData.CommitOid, "upload file", fileName, file, true)
-	case "regular":
-		return c.commitFile(repoId, repoType, preuploadData.CommitOid, "upload file", fileName, file, false)
-	default:
-		return fmt.Errorf("unknown upload mode: %s", preuploadData.Files[0].UploadMode)
+	op := &CommitOperationAdd{
+		PathInRepo: strings.TrimPrefix(pathInRepo, "/"),
+		Content:    r,
+		Size:       size,
 	}
+	return c.uploadOperation(ctx, repoId, repoType, op, opts)
 }
 
-// openFile opens a file and returns the file object, its size, and name
-func openFile(filePath string) (*os.File, int64, string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, 0, "", fmt.Errorf("error opening file: %w", err)
-	}
+// UploadBytes uploads in-memory content to pathInRepo.
+// opts.PathInRepo is ignored.
+func (c *HubClient) UploadBytes(ctx context.Context, repoId, repoType, pathInRepo string, data []byte, opts *UploadFileOptions) (*CommitInfo, error) {
+	return c.UploadReader(ctx, repoId, repoType, pathInRepo, bytes.NewReader(data), int64(len(data)), opts)
+}
 
-	fileInfo, err := file.Stat()
-	if err != nil {
-		file.Close()
-		return nil, 0, "", fmt.Errorf("error getting file info: %w", err)
+// uploadOperation commits a single add operation
+func (c *HubClient) uploadOperation(ctx context.Context, repoId, repoType string, op *CommitOperationAdd, opts *UploadFileOptions) (*CommitInfo, error) {
+	if op.PathInRepo == "" {
+		return nil, fmt.Errorf("path in repo must not be empty")
+	}
+	message := opts.CommitMessage
+	if message == "" {
+		message = fmt.Sprintf("upload %s", op.PathInRepo)
 	}
 
-	return file, fileInfo.Size(), filepath.Base(filePath), nil
+	return c.CreateCommit(ctx, repoId, repoType, []CommitOperation{op}, &CommitOptions{
+		CommitMessage:     message,
+		CommitDescription: opts.CommitDescription,
+		Revision:          opts.Revision,
+		CreatePR:          opts.CreatePR,
+		ParentCommit:      opts.ParentCommit,
+		Progress:          opts.Progress,
+	})
 }
 
 // PreuploadResponse defines the response from the pre-upload request
 type PreuploadResponse struct {
 	Files []struct {
-		Path        string `json:"path"`
-		UploadMode  string `json:"uploadMode"`
+		Path         string `json:"path"`
+		UploadMode   string `json:"uploadMode"`
 		ShouldIgnore bool   `json:"shouldIgnore"`
 	} `json:"files"`
 	CommitOid string `json:"commitOid"`
 }
 
-// preUpload sends the pre-upload request and parses the response
-func (c *HubClient) preUpload(repoId, repoType, fileName string, fileSize int64, sample string) (*PreuploadResponse, error) {
+// preuploadBatchSize is the maximum number of files sent in a single pre-upload request
+const preuploadBatchSize = 256
+
+// preUpload sends the pre-upload requests for all files, preuploadBatchSize files at a time, and sets their upload mode
+func (c *HubClient) preUpload(ctx context.Context, repoId, repoType, revision string, createPR bool, additions []*CommitOperationAdd, reporter ProgressReporter) (*PreuploadResponse, error) {
+	byPath := make(map[string]*CommitOperationAdd, len(additions))
+	for _, add := range additions {
+		if _, ok := byPath[add.PathInRepo]; ok {
+			return nil, fmt.Errorf("file added twice in the same commit: %s", add.PathInRepo)
+		}
+		byPath[add.PathInRepo] = add
+	}
+
+	var preuploadData PreuploadResponse
+	for start := 0; start < len(additions); start += preuploadBatchSize {
+		batch := additions[start:min(start+preuploadBatchSize, len(additions))]
+		batchData, err := c.preUploadBatch(ctx, repoId, repoType, revision, createPR, batch, reporter)
+		if err != nil {
+			return nil, err
+		}
+		preuploadData.Files = append(preuploadData.Files, batchData.Files...)
+		if preuploadData.CommitOid == "" {
+			preuploadData.CommitOid = batchData.CommitOid
+		}
+	}
+
+	// Map the results back to the operations by path
+	for _, file := range preuploadData.Files {
+		add, ok := byPath[file.Path]
+		if !ok {
+			continue
+		}
+		add.uploadMode = file.UploadMode
+		add.shouldIgnore = file.ShouldIgnore
+	}
+	for _, add := range additions {
+		if add.uploadMode == "" && !add.shouldIgnore {
+			return nil, fmt.Errorf("pre-upload response is missing file: %s", add.PathInRepo)
+		}
+		if add.uploadMode != "" && add.uploadMode != "lfs" && add.uploadMode != "regular" {
+			return nil, fmt.Errorf("unknown upload mode: %s", add.uploadMode)
+		}
+	}
+
+	return &preuploadData, nil
+}
+
+// preUploadBatch sends a single pre-upload request for the given files
+func (c *HubClient) preUploadBatch(ctx context.Context, repoId, repoType, revision string, createPR bool, batch []*CommitOperationAdd, reporter ProgressReporter) (*PreuploadResponse, error) {
+	files := make([]map[string]interface{}, 0, len(batch))
+	progresses := make([]*fileProgress, 0, len(batch))
+	for _, add := range batch {
+		progress := newFileProgress(reporter, add.PathInRepo, PhasePreupload, add.uploadInfo.Size)
+		progress.start()
+		progresses = append(progresses, progress)
+		files = append(files, map[string]interface{}{
+			"path":   add.PathInRepo,
+			"size":   add.uploadInfo.Size,
+			"sample": base64.StdEncoding.EncodeToString(add.uploadInfo.Sample),
+		})
+	}
 	preuploadBody := map[string]interface{}{
-		"files": []map[string]interface{}{
-			{
-				"path":   fileName,
-				"size":   fileSize,
-				"sample": sample,
-			},
-		},
+		"files": files,
+	}
+	preuploadURL := fmt.Sprintf("/api/%ss/%s/preupload/%s", repoType, repoId, escapeRevision(revision))
+	if createPR {
+		preuploadURL += "?create_pr=1"
 	}
-	preuploadURL := fmt.Sprintf("/api/%ss/%s/preupload/main", repoType, repoId)
 
-	preuploadResp, err := c.doRequest("POST", preuploadURL, preuploadBody, nil)
+	preuploadResp, err := c.doRequest(ctx, "POST", preuploadURL, preuploadBody, nil)
 	if err != nil {
 		return nil, fmt.Errorf("pre-upload request failed: %w", err)
 	}
 	defer preuploadResp.Body.Close()
 
 	if preuploadResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("pre-upload failed: status code %d", preuploadResp.StatusCode)
+		return nil, fmt.Errorf("pre-upload failed: %w", newAPIError(preuploadResp, repoId))
 	}
 
 	var preuploadData PreuploadResponse
@@ -102,43 +183,147 @@ func (c *HubClient) preUpload(repoId, repoType, fileName string, fileSize int64,
 		return nil, fmt.Errorf("error decoding pre-upload response: %w", err)
 	}
 
+	for _, progress := range progresses {
+		progress.done()
+	}
+
 	return &preuploadData, nil
 }
 
+// lfsBatchSize is the maximum number of objects sent in a single LFS batch request
+const lfsBatchSize = 256
+
+// lfsUpload is an LFS object that the batch endpoint asked us to upload
+type lfsUpload struct {
+	transfer string
+	object   LFSObject
+	add      *CommitOperationAdd
+}
+
+// uploadLFSFiles uploads the content of all LFS files with up to UploadConcurrency workers.
+// A failed upload cancels the ones in flight, and all failures are reported together.
+func (c *HubClient) uploadLFSFiles(ctx context.Context, repoId, repoType, revision string, additions []*CommitOperationAdd, reporter ProgressReporter) error {
+	var lfsFiles []*CommitOperationAdd
+	for _, add := range additions {
+		if add.uploadMode == "lfs" && !add.shouldIgnore {
+			lfsFiles = append(lfsFiles, add)
+		}
+	}
 
-// ploadLFS handles the upload of a file to LFS
-func (c *HubClient) uploadLFS(repoId, repoType, fileName string, fileData io.Reader) error {
-    // 1. Calculate SHA256 Hash
-    fileContent, err := ioutil.ReadAll(fileData)
-    if err != nil {
-        return fmt.Errorf("error reading file data: %w", err)
-    }
-    fileHash := sha256.Sum256(fileContent)
-    hashString := fmt.Sprintf("%x", fileHash)
-
-    // 2. Make LFS Batch Request
-    lfsBatchBody := map[string]interface{}{
-        "operation": "upload",
-        "transfers": []string{"basic"}, // We can add "multipart" for larger files later
+	// 1. Ask the batch endpoint which objects need to be uploaded
+	var uploads []lfsUpload
+	for start := 0; start < len(lfsFiles); start += lfsBatchSize {
+		batch := lfsFiles[start:min(start+lfsBatchSize, len(lfsFiles))]
+		batchUploads, err := c.lfsBatch(ctx, repoId, repoType, revision, batch)
+		if err != nil {
+			return err
+		}
+		uploads = append(uploads, batchUploads...)
+	}
+
+	// Files already on the storage are reported as uploaded right away. Files with the same content as an uploaded
+	// file are uploaded once, and reported along with it.
+	toUpload := make(map[string]bool, len(uploads))
+	for _, upload := range uploads {
+		toUpload[upload.add.uploadInfo.Sha256] = true
+	}
+	duplicates := map[string][]*CommitOperationAdd{}
+	for _, add := range lfsFiles {
+		if !toUpload[add.uploadInfo.Sha256] {
+			newFileProgress(reporter, add.PathInRepo, PhaseUploading, add.uploadInfo.Size).done()
+		} else {
+			duplicates[add.uploadInfo.Sha256] = append(duplicates[add.uploadInfo.Sha256], add)
+		}
+	}
+
+	// 2. Upload them in parallel
+	err := runWorkers(ctx, c.uploadConcurrency(), uploads, func(ctx context.Context, upload lfsUpload) error {
+		progress := newFileProgress(reporter, upload.add.PathInRepo, PhaseUploading, upload.add.uploadInfo.Size)
+		progress.start()
+		if err := c.uploadAndVerifyLFS(ctx, upload.transfer, upload.object, upload.add, progress); err != nil {
+			return fmt.Errorf("%s: %w", upload.add.PathInRepo, err)
+		}
+		progress.done()
+		for _, add := range duplicates[upload.add.uploadInfo.Sha256] {
+			if add != upload.add {
+				newFileProgress(reporter, add.PathInRepo, PhaseUploading, add.uploadInfo.Size).done()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("LFS upload failed: %w", err)
+	}
+	return nil
+}
+
+// lfsBatch sends a single LFS batch request for the given files and returns the objects to upload
+func (c *HubClient) lfsBatch(ctx context.Context, repoId, repoType, revision string, lfsFiles []*CommitOperationAdd) ([]lfsUpload, error) {
+	// The oid and size were computed while hashing the files, identical files are only sent once
+	byOid := make(map[string]*CommitOperationAdd, len(lfsFiles))
+	objects := make([]map[string]interface{}, 0, len(lfsFiles))
+	for _, add := range lfsFiles {
+		if _, ok := byOid[add.uploadInfo.Sha256]; ok {
+			continue
+		}
+		byOid[add.uploadInfo.Sha256] = add
+		objects = append(objects, map[string]interface{}{
+			"oid":  add.uploadInfo.Sha256,
+			"size": add.uploadInfo.Size,
+		})
+	}
+
+	lfsBatchBody := map[string]interface{}{
+		"operation": "upload",
+		"transfers": []string{"basic", "multipart"},
 		"hash_algo": "sha_256",
-		"ref": map[string]interface{}{"name": "main"},
-        "objects": []map[string]interface{}{
-            {
-                "oid":  hashString,
-                "size": len(fileContent),
-            },
-        },
-    }
-	lfsBatchURL := fmt.Sprintf("/%s.git/info/lfs/objects/batch", repoId)
-
-    lfsBatchResp, err := c.doRequest("POST", lfsBatchURL, lfsBatchBody, lfsHeaders())
-    if err != nil {
-        return fmt.Errorf("LFS batch request failed: %w", err)
-    }
-    defer lfsBatchResp.Body.Close()
-
-    // 3. Parse Batch Response and Upload
-	return c.handleLFSResponse(lfsBatchResp, fileName, hashString, fileContent)
+		"ref":       map[string]interface{}{"name": revision},
+		"objects":   objects,
+	}
+	lfsBatchURL := fmt.Sprintf("/%s%s.git/info/lfs/objects/batch", repoTypeURLPrefix(repoType), repoId)
+
+	resp, err := c.doRequest(ctx, "POST", lfsBatchURL, lfsBatchBody, lfsHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("LFS batch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("LFS batch API request failed: %w", newAPIError(resp, repoId))
+	}
+
+	var batchResponse LFSBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResponse); err != nil {
+		return nil, fmt.Errorf("error decoding LFS batch response: %w", err)
+	}
+
+	var uploads []lfsUpload
+	var errs []error
+	for _, lfsObject := range batchResponse.Objects {
+		add, ok := byOid[lfsObject.Oid]
+		if !ok {
+			continue
+		}
+		delete(byOid, lfsObject.Oid)
+
+		if lfsObject.Error != nil {
+			errs = append(errs, fmt.Errorf("LFS batch error for %s: %s (code %d)", add.PathInRepo, lfsObject.Error.Message, lfsObject.Error.Code))
+			continue
+		}
+		if lfsObject.Actions == nil || lfsObject.Actions.Upload == nil {
+			// Already on the storage
+			continue
+		}
+		uploads = append(uploads, lfsUpload{transfer: batchResponse.Transfer, object: lfsObject, add: add})
+	}
+	for _, add := range byOid {
+		errs = append(errs, fmt.Errorf("LFS batch response is missing file: %s", add.PathInRepo))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return uploads, nil
 }
 
 // lfsHeaders returns the headers required for LFS requests
@@ -151,211 +336,97 @@ func lfsHeaders() map[string]string {
 
 // LFSBatchResponse defines the response structure from an LFS batch request
 type LFSBatchResponse struct {
-	Objects []LFSObject `json:"objects"`
+	Transfer string      `json:"transfer"` // "basic" or "multipart"
+	Objects  []LFSObject `json:"objects"`
 }
 
 // LFSObject defines an individual object in an LFS batch request
 type LFSObject struct {
-	Oid     string `json:"oid"`
-	Error   *struct { // Include Error struct
+	Oid   string    `json:"oid"`
+	Error *struct { // Include Error struct
 		Code    int    `json:"code"`
 		Message string `json:"message"`
 	} `json:"error"`
 	Actions *struct { // Pointer to handle missing actions
-		Upload *struct {
-			Href string `json:"href"`
-		} `json:"upload"`
-		Verify *struct {
-			Href   string            `json:"href"`
-			Header map[string]string `json:"header"`
-		} `json:"verify"`
+		Upload *LFSAction `json:"upload"`
+		Verify *LFSAction `json:"verify"`
 	} `json:"actions"`
 }
 
-// handleLFSResponse processes the LFS batch response and uploads the file
-func (c *HubClient) handleLFSResponse(resp *http.Response, fileName, hashString string, fileContent []byte) error {
-	if resp.StatusCode != http.StatusOK {
-		responseBody, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("LFS batch API request failed: %s (Status Code: %d)", string(responseBody), resp.StatusCode)
-	}
-
-	var batchResponse LFSBatchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&batchResponse); err != nil {
-		return fmt.Errorf("error decoding LFS batch response: %w", err)
-	}
-
-	if len(batchResponse.Objects) == 0 {
-		return fmt.Errorf("LFS batch response contains no objects")
-	}
-
-	lfsObject := batchResponse.Objects[0]
-	if lfsObject.Error != nil {
-		return fmt.Errorf("LFS batch error: %s (code %d)", lfsObject.Error.Message, lfsObject.Error.Code)
-	}
-
-	if lfsObject.Actions == nil || lfsObject.Actions.Upload == nil {
-		fmt.Printf("File %s already exists in LFS (oid: %s)\n", fileName, hashString)
-		return nil
-	}
-
-	return c.uploadAndVerifyLFS(lfsObject, fileContent, hashString)
+// LFSAction defines an action (upload or verify) returned by an LFS batch request
+type LFSAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
 }
 
-// uploadAndVerifyLFS uploads the file to the provided LFS URL and verifies it
-func (c *HubClient) uploadAndVerifyLFS(lfsObject LFSObject, fileContent []byte, hashString string) error {
-	uploadURL := lfsObject.Actions.Upload.Href
-
-	if err := c.uploadFileToLFS(uploadURL, fileContent); err != nil {
+// uploadAndVerifyLFS uploads the file to the provided LFS URL and verifies it.
+// Only the bytes that were hashed are sent, even if the file grew since.
+func (c *HubClient) uploadAndVerifyLFS(ctx context.Context, transfer string, lfsObject LFSObject, add *CommitOperationAdd, progress *fileProgress) error {
+	content, _, closer, err := add.openContent()
+	if err != nil {
 		return err
 	}
+	defer closer.Close()
+	size := add.uploadInfo.Size
+
+	if transfer == "multipart" {
+		if err := c.uploadMultipartLFS(ctx, lfsObject.Actions.Upload, add.uploadInfo.Sha256, content, size, progress); err != nil {
+			return err
+		}
+	} else {
+		if err := c.uploadFileToLFS(ctx, lfsObject.Actions.Upload.Href, content, size, progress); err != nil {
+			return err
+		}
+	}
 
 	if lfsObject.Actions.Verify != nil {
 		verifyURL := lfsObject.Actions.Verify.Href
 		verifyBody := map[string]interface{}{
-			"oid": hashString, 
-			"size": len(fileContent),
+			"oid":  add.uploadInfo.Sha256,
+			"size": size,
 		}
 
-		return c.verifyLFSUpload(verifyURL, verifyBody)
+		return c.verifyLFSUpload(ctx, verifyURL, verifyBody)
 	}
 
 	return nil
 }
 
-// uploadFileToLFS uploads the file content to the provided URL
-func (c *HubClient) uploadFileToLFS(uploadURL string, fileContent []byte) error {
-	req, err := http.NewRequest("PUT", uploadURL, bytes.NewReader(fileContent))
+// uploadFileToLFS streams the file content to the provided URL, it is retried on transient errors
+func (c *HubClient) uploadFileToLFS(ctx context.Context, uploadURL string, content io.ReaderAt, size int64, progress *fileProgress) error {
+	body, getBody := rewindableBody(content, 0, size, progress)
+	req, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, body)
 	if err != nil {
 		return fmt.Errorf("error creating upload request: %w", err)
 	}
+	req.ContentLength = size
+	req.GetBody = getBody
 	req.Header.Set("Content-Type", "application/octet-stream")
 
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return fmt.Errorf("LFS upload request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		responseBody, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("LFS upload failed: %s", string(responseBody))
+		return fmt.Errorf("LFS upload failed: %w", newAPIError(resp, ""))
 	}
 
 	return nil
 }
 
 // verifyLFSUpload verifies the LFS upload by sending a verification request
-func (c *HubClient) verifyLFSUpload(verifyURL string, verifyBody map[string]interface{}) error {
-	verifyResp, err := c.doRequest("POST", verifyURL, verifyBody, nil)
+func (c *HubClient) verifyLFSUpload(ctx context.Context, verifyURL string, verifyBody map[string]interface{}) error {
+	verifyResp, err := c.doRequest(ctx, "POST", verifyURL, verifyBody, nil)
 	if err != nil {
 		return fmt.Errorf("LFS verify request failed: %w", err)
 	}
 	defer verifyResp.Body.Close()
 
 	if verifyResp.StatusCode != http.StatusOK {
-		responseBody, _ := ioutil.ReadAll(verifyResp.Body)
-		return fmt.Errorf("LFS verify failed: status code %d, body: %s", verifyResp.StatusCode, responseBody)
+		return fmt.Errorf("LFS verify failed: %w", newAPIError(verifyResp, ""))
 	}
 
 	return nil
 }
-
-
-// commitFile commits a single file directly to the repository.
-func (c *HubClient) commitFile(repoId, repoType, commitOid, commitMessage, fileName string, fileData io.Reader, isLFS bool) error {
-	var operationsJSON []byte
-    var fileSize int64
-
-	if isLFS {
-		if _, err := fileData.(*os.File).Seek(0, io.SeekStart); err != nil { // Check if fileData is *os.File
-            return fmt.Errorf("error seeking file for LFS hash: %w", err)
-        }
-		fileContent, err := ioutil.ReadAll(fileData)
-        if err != nil {
-            return fmt.Errorf("error reading file data for LFS commit: %w", err)
-        }
-
-		fileHash := sha256.Sum256(fileContent)
-        hashString := fmt.Sprintf("%x", fileHash)
-        fileSize = int64(len(fileContent))
-
-		operationsJSON, _ = json.Marshal(map[string]interface{}{
-            "key": "lfsFile",
-            "value": map[string]interface{}{
-                "path": fileName,
-                "algo": "sha256",
-                "size": fileSize,
-                "oid":  hashString,
-            },
-        })
-	} else {
-		// Encode the file data as base64
-		fileContent, err := ioutil.ReadAll(fileData)
-		if err != nil {
-			return fmt.Errorf("error reading file data: %w", err)
-		}
-		base64Content := base64.StdEncoding.EncodeToString(fileContent)
-
-		operationsJSON, _ = json.Marshal(map[string]interface{}{
-			"key": "file",
-			"value": map[string]interface{}{ // "value" is a single object now
-				"path":     fileName,
-				"encoding": "base64",
-				"content":  base64Content,
-			},
-		})
-	}
-
-	// Commit the file
-	headerJSON, _ := json.Marshal(map[string]interface{}{
-		"key": "header",
-		"value": map[string]interface{}{
-			"summary": commitMessage,
-			"parentCommit": commitOid,
-		},
-	})
-
-	// Create NDJSON Request Body
-	requestBody := []byte(string(headerJSON) + "\n\n" + string(operationsJSON))
-	commitURL := fmt.Sprintf("%s/api/%ss/%s/commit/main", c.BaseURL, repoType, repoId)
-
-	commitReq, err := http.NewRequest("POST", commitURL, bytes.NewReader(requestBody))
-	if err != nil {
-		return fmt.Errorf("error creating commit request: %w", err)
-	}
-	commitReq.Header.Set("Authorization", c.Auth.Header())
-	commitReq.Header.Set("Content-Type", "application/x-ndjson") 
-
-	commitResp, err := c.HTTPClient.Do(commitReq)
-	if err != nil {
-		return fmt.Errorf("commit request failed: %w", err)
-	}
-	defer commitResp.Body.Close()
-
-	if commitResp.StatusCode != http.StatusOK {
-		responseBody, _ := ioutil.ReadAll(commitResp.Body)
-		fmt.Println("Commit Response Body:", string(responseBody))
-		return fmt.Errorf("commit failed: status code %d", commitResp.StatusCode)
-	}
-
-	fmt.Printf("File %s committed successfully!\n", fileName)
-	return nil
-}
-
-
-// Helper function to get base64 encoded sample of the file
-func getFileSample(filePath string) (string, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return "", fmt.Errorf("error opening file: %w", err)
-	}
-	defer file.Close()
-
-	sampleBytes := make([]byte, 512) // Read up to 512 bytes
-	n, err := file.Read(sampleBytes)
-	if err != nil && err != io.EOF {
-		return "", fmt.Errorf("error reading file sample: %w", err)
-	}
-
-	return base64.StdEncoding.EncodeToString(sampleBytes[:n]), nil
-}
\ No newline at end of file
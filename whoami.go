@@ -0,0 +1,134 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// WhoAmIResponse describes the user owning the token and what the token is allowed to do
+type WhoAmIResponse struct {
+	Type     string      `json:"type"` // "user", or "org" for organization tokens
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Fullname string      `json:"fullname"`
+	Email    string      `json:"email"`
+	Orgs     []WhoAmIOrg `json:"orgs"`
+	Auth     WhoAmIAuth  `json:"auth"`
+}
+
+// WhoAmIOrg is an organization the user belongs to
+type WhoAmIOrg struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Fullname  string `json:"fullname"`
+	RoleInOrg string `json:"roleInOrg"` // "admin", "write", "contributor" or "read"
+}
+
+// WhoAmIAuth describes how the request was authenticated
+type WhoAmIAuth struct {
+	Type        string           `json:"type"`
+	AccessToken *AccessTokenInfo `json:"accessToken,omitempty"`
+}
+
+// AccessTokenInfo describes a user access token
+type AccessTokenInfo struct {
+	DisplayName string                `json:"displayName"`
+	Role        string                `json:"role"` // "read", "write" or "fineGrained"
+	CreatedAt   string                `json:"createdAt"`
+	FineGrained *FineGrainedTokenInfo `json:"fineGrained,omitempty"`
+}
+
+// FineGrainedTokenInfo lists the permissions of a fine-grained token
+type FineGrainedTokenInfo struct {
+	CanReadGatedRepos bool         `json:"canReadGatedRepos"`
+	Global            []string     `json:"global"`
+	Scoped            []TokenScope `json:"scoped"`
+}
+
+// TokenScope lists the permissions of a fine-grained token on a user, an organization or a repository
+type TokenScope struct {
+	Entity      TokenScopeEntity `json:"entity"`
+	Permissions []string         `json:"permissions"` // e.g. "repo.content.read", "repo.write"
+}
+
+// TokenScopeEntity is the user, organization or repository a scope applies to
+type TokenScopeEntity struct {
+	ID   string `json:"_id"`
+	Type string `json:"type"` // "user", "org", "model", "dataset" or "space"
+	Name string `json:"name"`
+}
+
+// WhoAmI returns the user or organization owning the token of the client, along with the permissions of the token.
+func (c *HubClient) WhoAmI(ctx context.Context) (*WhoAmIResponse, error) {
+	if err := c.requireAuth("whoami"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/api/whoami-v2", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("whoami request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp, "")
+	}
+
+	var whoami WhoAmIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&whoami); err != nil {
+		return nil, fmt.Errorf("error decoding whoami: %w", err)
+	}
+	return &whoami, nil
+}
+
+// CanWrite reports whether the token of the client can create repositories and push to the repositories of namespace,
+// the user owning the token or one of their organizations. It returns false for anonymous clients, and for organization
+// contributors, who can only push to the repositories they created.
+func (c *HubClient) CanWrite(ctx context.Context, namespace string) (bool, error) {
+	if c.Auth.IsAnonymous() {
+		return false, nil
+	}
+	whoami, err := c.WhoAmI(ctx)
+	if err != nil {
+		return false, err
+	}
+	return whoami.canWrite(namespace), nil
+}
+
+// canWrite checks both that the user can write to the namespace and that the token allows it
+func (w *WhoAmIResponse) canWrite(namespace string) bool {
+	memberCanWrite := namespace == w.Name
+	for _, org := range w.Orgs {
+		if org.Name == namespace {
+			memberCanWrite = org.RoleInOrg == "admin" || org.RoleInOrg == "write"
+		}
+	}
+	if !memberCanWrite {
+		return false
+	}
+
+	token := w.Auth.AccessToken
+	if token == nil {
+		// Not authenticated with an access token, e.g. an OAuth token: the user permissions apply
+		return true
+	}
+	switch token.Role {
+	case "write":
+		return true
+	case "fineGrained":
+		if token.FineGrained == nil {
+			return false
+		}
+		for _, scope := range token.FineGrained.Scoped {
+			if (scope.Entity.Type == "user" || scope.Entity.Type == "org") && scope.Entity.Name == namespace {
+				return slices.Contains(scope.Permissions, "repo.write")
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,76 @@
+package huggingface
+
+import "testing"
+
+func TestWhoAmICanWrite(t *testing.T) {
+	orgs := []WhoAmIOrg{
+		{Name: "admin-org", RoleInOrg: "admin"},
+		{Name: "write-org", RoleInOrg: "write"},
+		{Name: "contributor-org", RoleInOrg: "contributor"},
+		{Name: "read-org", RoleInOrg: "read"},
+	}
+	withToken := func(token *AccessTokenInfo) *WhoAmIResponse {
+		return &WhoAmIResponse{Type: "user", Name: "alice", Orgs: orgs, Auth: WhoAmIAuth{Type: "access_token", AccessToken: token}}
+	}
+	fineGrained := func(scopes ...TokenScope) *AccessTokenInfo {
+		return &AccessTokenInfo{Role: "fineGrained", FineGrained: &FineGrainedTokenInfo{Scoped: scopes}}
+	}
+	writeToken := &AccessTokenInfo{Role: "write"}
+	readToken := &AccessTokenInfo{Role: "read"}
+
+	tests := []struct {
+		name      string
+		whoami    *WhoAmIResponse
+		namespace string
+		want      bool
+	}{
+		{name: "write token on own namespace", whoami: withToken(writeToken), namespace: "alice", want: true},
+		{name: "read token on own namespace", whoami: withToken(readToken), namespace: "alice", want: false},
+		{name: "write token on other user", whoami: withToken(writeToken), namespace: "bob", want: false},
+		{name: "write token as org admin", whoami: withToken(writeToken), namespace: "admin-org", want: true},
+		{name: "write token as org writer", whoami: withToken(writeToken), namespace: "write-org", want: true},
+		{name: "write token as org contributor", whoami: withToken(writeToken), namespace: "contributor-org", want: false},
+		{name: "write token as org reader", whoami: withToken(writeToken), namespace: "read-org", want: false},
+		{name: "read token as org admin", whoami: withToken(readToken), namespace: "admin-org", want: false},
+		{
+			name:      "fine-grained token with repo.write",
+			whoami:    withToken(fineGrained(TokenScope{Entity: TokenScopeEntity{Type: "user", Name: "alice"}, Permissions: []string{"repo.content.read", "repo.write"}})),
+			namespace: "alice",
+			want:      true,
+		},
+		{
+			name:      "fine-grained token without repo.write",
+			whoami:    withToken(fineGrained(TokenScope{Entity: TokenScopeEntity{Type: "user", Name: "alice"}, Permissions: []string{"repo.content.read"}})),
+			namespace: "alice",
+			want:      false,
+		},
+		{
+			name:      "fine-grained token scoped to another namespace",
+			whoami:    withToken(fineGrained(TokenScope{Entity: TokenScopeEntity{Type: "org", Name: "write-org"}, Permissions: []string{"repo.write"}})),
+			namespace: "alice",
+			want:      false,
+		},
+		{
+			name:      "fine-grained token with repo.write on an org",
+			whoami:    withToken(fineGrained(TokenScope{Entity: TokenScopeEntity{Type: "org", Name: "write-org"}, Permissions: []string{"repo.write"}})),
+			namespace: "write-org",
+			want:      true,
+		},
+		{
+			name:      "fine-grained token with repo.write as org reader",
+			whoami:    withToken(fineGrained(TokenScope{Entity: TokenScopeEntity{Type: "org", Name: "read-org"}, Permissions: []string{"repo.write"}})),
+			namespace: "read-org",
+			want:      false,
+		},
+		{name: "fine-grained token without scopes", whoami: withToken(&AccessTokenInfo{Role: "fineGrained"}), namespace: "alice", want: false},
+		{name: "no access token on own namespace", whoami: withToken(nil), namespace: "alice", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.whoami.canWrite(tt.namespace); got != tt.want {
+				t.Errorf("canWrite(%q) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// runWorkers calls fn for every job, with up to `workers` calls running at the same time.
+// The first failure cancels the context of the other calls and stops handing out jobs.
+// All failures are joined, leaving out the cancellations they caused.
+func runWorkers[T any](ctx context.Context, workers int, jobs []T, fn func(context.Context, T) error) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+	workers = max(1, min(workers, len(jobs)))
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	queue := make(chan T)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if err := fn(workCtx, job); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+
+	sent := 0
+sendJobs:
+	for _, job := range jobs {
+		select {
+		case queue <- job:
+			sent++
+		case <-workCtx.Done():
+			break sendJobs
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	var failures []error
+	for _, err := range errs {
+		if errors.Is(err, context.Canceled) && ctx.Err() == nil {
+			continue // cancelled because another job failed
+		}
+		failures = append(failures, err)
+	}
+	if len(failures) == 0 && sent < len(jobs) {
+		return ctx.Err() // cancelled by the caller before every job was handed out
+	}
+	return errors.Join(failures...)
+}
+
+// ctxReader fails reads once its context is done, so that hashing large files can be cancelled
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// contextReader wraps r so that reads fail with the error of ctx once it is done
+func contextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &ctxReader{ctx: ctx, r: r}
+}
+
+func (r *ctxReader) Read(b []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(b)
+}